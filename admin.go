@@ -0,0 +1,129 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mailru/easyjson"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/game"
+)
+
+// AdminMiddleware lets through only requests with "Authorization: Bearer <token>" header.
+func AdminMiddleware(next http.Handler, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// writeJSON writes the object as JSON response.
+func writeJSON(w http.ResponseWriter, v easyjson.Marshaler) {
+	j, err := easyjson.Marshal(v)
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(j)
+}
+
+// @Summary Список комнат
+// @Description Возвращает живые комнаты с игроками, их очками, временем игры и статусом
+// @ID get-admin-rooms
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} game.RoomInfo "Успешно"
+// @Failure 401 "Неверный токен"
+// @Router /admin/rooms [GET]
+func ListRooms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, game.ListRooms())
+}
+
+// @Summary Комната
+// @Description Возвращает информацию о комнате и текущий стейт игры
+// @ID get-admin-room
+// @Produce json
+// @Security AdminToken
+// @Param id query string true "ID комнаты"
+// @Success 200 {object} game.RoomDetails "Успешно"
+// @Failure 401 "Неверный токен"
+// @Failure 404 "Комната не найдена"
+// @Router /admin/room [GET]
+func InspectRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	d, err := game.InspectRoom(r.URL.Query().Get("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, d)
+}
+
+// @Summary Завершить игру
+// @Description Завершает игру в комнате без изменения рейтинга, монет и статистики, ждущая игроков комната закрывается
+// @ID post-admin-room-finish
+// @Security AdminToken
+// @Param id query string true "ID комнаты"
+// @Success 200 "Успешно"
+// @Failure 401 "Неверный токен"
+// @Failure 404 "Комната не найдена"
+// @Router /admin/room/finish [POST]
+func TerminateRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	err := game.TerminateRoom(r.URL.Query().Get("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+}
+
+// @Summary Выгнать игрока
+// @Description Выгоняет игрока из комнаты, он проигрывает
+// @ID post-admin-room-kick
+// @Security AdminToken
+// @Param id query string true "ID комнаты"
+// @Param uid query int true "ID пользователя"
+// @Success 200 "Успешно"
+// @Failure 400 "Неправильные параметры или игрок - бот"
+// @Failure 401 "Неверный токен"
+// @Failure 404 "Комната или игрок не найдены"
+// @Router /admin/room/kick [POST]
+func KickPlayer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	uID, err := strconv.ParseUint(r.URL.Query().Get("uid"), 10, 0)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	err = game.KickPlayer(r.URL.Query().Get("id"), uint(uID))
+	switch err {
+	case nil:
+	case game.ErrKickBot:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"sort"
+
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+
+	"game/models"
+)
+
+// SaveMatchResults saves the match to history and updates stats, coins and ratings
+// of its participants in one transaction. The match is saved only once, so repeated
+// calls with the same room ID do nothing.
+func SaveMatchResults(dm *db.DatabaseManager, m *models.Match) error {
+	dbo, err := dm.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := dbo.Beginx()
+	if err != nil {
+		return err
+	}
+	res, err := tx.Exec(`
+		INSERT INTO matches (room_id, mode, started, ended, end_reason, seed, casual)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (room_id) DO NOTHING`,
+		m.RoomID, m.Mode, m.Started, m.Ended, m.EndReason, m.Seed, m.Casual,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if n == 0 { // already saved
+		return tx.Rollback()
+	}
+
+	// the same order of updates in all transactions prevents deadlocks
+	participants := make([]*models.MatchParticipant, len(m.Participants))
+	copy(participants, m.Participants)
+	sort.Slice(participants, func(i, j int) bool { return participants[i].UID < participants[j].UID })
+	for _, p := range participants {
+		err = saveParticipant(tx, m.RoomID, p, !m.Casual)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Ping checks if the database is reachable.
+func Ping(dm *db.DatabaseManager) error {
+	dbo, err := dm.DB()
+	if err != nil {
+		return err
+	}
+	return dbo.Ping()
+}
+
+// saveParticipant saves the participant of the match, his stats, coins and rating
+// are updated only if rewarded.
+func saveParticipant(e execer, roomID string, p *models.MatchParticipant, rewarded bool) error {
+	if rewarded {
+		err := rewardParticipant(e, p)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := e.Exec(`
+		INSERT INTO match_participants (room_id, user_id, player_num, team, place, score, game_result, coins, rating_delta, flagged)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		roomID, p.UID, p.PlayerNum, p.Team, p.Place, p.Score, p.GameResult, p.Coins, p.RatingDelta, p.Flagged,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func rewardParticipant(e execer, p *models.MatchParticipant) error {
+	err := updateStats(e, &models.Record{
+		UID:        p.UID,
+		Record:     p.Score,
+		GameResult: p.GameResult,
+	})
+	if err != nil {
+		return err
+	}
+	if p.Coins != 0 {
+		err = changeUserCoinAmount(e, p.UID, p.Coins)
+		if err != nil {
+			return err
+		}
+	}
+	return updateRating(e, p.UID, p.RatingDelta)
+}
+
+// GetUserMatches returns the page of user's matches, the latest first.
+func GetUserMatches(dm *db.DatabaseManager, uID uint, limit, offset int) ([]*models.Match, error) {
+	dbo, err := dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		models.Match
+		models.MatchParticipant
+	}{}
+	err = dbo.Select(&rows, `
+		SELECT m.room_id, m.mode, m.started, m.ended, m.end_reason, m.seed, m.casual,
+			p.user_id, p.player_num, p.team, p.place, p.score, p.game_result, p.coins, p.rating_delta, p.flagged
+		FROM (
+			SELECT matches.*
+			FROM matches
+			JOIN match_participants USING (room_id)
+			WHERE match_participants.user_id = $1
+			ORDER BY ended DESC, room_id
+			LIMIT $2 OFFSET $3
+		) AS m
+		JOIN match_participants AS p USING (room_id)
+		ORDER BY m.ended DESC, m.room_id, p.place, p.team, p.player_num`,
+		uID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*models.Match, 0, limit)
+	for i := range rows {
+		if len(matches) == 0 || matches[len(matches)-1].RoomID != rows[i].RoomID {
+			m := rows[i].Match
+			matches = append(matches, &m)
+		}
+		p := rows[i].MatchParticipant
+		last := matches[len(matches)-1]
+		last.Participants = append(last.Participants, &p)
+	}
+
+	return matches, nil
+}
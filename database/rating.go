@@ -0,0 +1,38 @@
+package database
+
+import (
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+)
+
+func GetUserRating(dm *db.DatabaseManager, uID uint) (int, error) {
+	dbo, err := dm.DB()
+	if err != nil {
+		return 0, err
+	}
+	var rating int
+	err = dbo.Get(&rating, `
+		SELECT rating
+		FROM user_profile
+		WHERE user_id = $1`,
+		uID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return rating, nil
+}
+
+func updateRating(e execer, uID uint, delta int) error {
+	_, err := e.Exec(`
+		UPDATE user_profile
+		SET rating = rating + $1
+		WHERE user_id = $2`,
+		delta, uID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 
 	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
@@ -8,11 +9,21 @@ import (
 	"game/models"
 )
 
+// execer is implemented by both database and transaction objects.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 func UpdateStats(dm *db.DatabaseManager, r *models.Record) error {
 	dbo, err := dm.DB()
 	if err != nil {
 		return err
 	}
+
+	return updateStats(dbo, r)
+}
+
+func updateStats(e execer, r *models.Record) error {
 	q := `
 		UPDATE user_profile
 		SET record = GREATEST($1, record), `
@@ -28,7 +39,7 @@ func UpdateStats(dm *db.DatabaseManager, r *models.Record) error {
 	}
 	q += `
 		WHERE user_id = $2`
-	_, err = dbo.Exec(q, r.Record, r.UID)
+	_, err := e.Exec(q, r.Record, r.UID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	db "github.com/go-park-mail-ru/2018_2_DeadMolesStudio/database"
+)
+
+func SaveReplay(dm *db.DatabaseManager, roomID string, seed int64, data []byte) error {
+	dbo, err := dm.DB()
+	if err != nil {
+		return err
+	}
+	_, err = dbo.Exec(`
+		INSERT INTO replay (room_id, seed, data)
+		VALUES ($1, $2, $3)`,
+		roomID, seed, data,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GetReplay(dm *db.DatabaseManager, roomID string) ([]byte, error) {
+	dbo, err := dm.DB()
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	err = dbo.Get(&data, `
+		SELECT data
+		FROM replay
+		WHERE room_id = $1`,
+		roomID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,39 @@
+-- Schema changes required by game-service.
+-- user_profile is owned by the main API service, so these statements
+-- must be added to its migrations; game-service does not apply them itself.
+
+ALTER TABLE user_profile
+	ADD COLUMN IF NOT EXISTS rating INTEGER NOT NULL DEFAULT 1500;
+
+CREATE TABLE IF NOT EXISTS replay (
+	room_id UUID PRIMARY KEY,
+	seed BIGINT NOT NULL,
+	data BYTEA NOT NULL, -- JSON with start info and input log
+	created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS matches (
+	room_id UUID PRIMARY KEY,
+	mode TEXT NOT NULL DEFAULT 'solo', -- solo or team
+	started TIMESTAMPTZ NOT NULL,
+	ended TIMESTAMPTZ NOT NULL,
+	end_reason TEXT NOT NULL, -- time_over, disconnected or admin_terminated
+	seed BIGINT NOT NULL,
+	casual BOOLEAN NOT NULL DEFAULT FALSE -- games with bots or stopped by admin don't change stats, coins and rating
+);
+
+CREATE TABLE IF NOT EXISTS match_participants (
+	room_id UUID NOT NULL REFERENCES matches (room_id),
+	user_id INTEGER NOT NULL,
+	player_num INTEGER NOT NULL,
+	team INTEGER NOT NULL DEFAULT 0, -- 1-2 in team mode
+	place INTEGER NOT NULL,
+	score INTEGER NOT NULL,
+	game_result INTEGER NOT NULL, -- 0 win, 1 loss, 2 draw
+	coins INTEGER NOT NULL,
+	rating_delta INTEGER NOT NULL,
+	flagged BOOLEAN NOT NULL DEFAULT FALSE, -- suspected of cheating
+	PRIMARY KEY (room_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS match_participants_user_id_idx ON match_participants (user_id);
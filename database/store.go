@@ -9,7 +9,12 @@ func ChangeUserCoinAmount(dm *db.DatabaseManager, uID uint, sum int) error {
 	if err != nil {
 		return err
 	}
-	_, err = dbo.Exec(`
+
+	return changeUserCoinAmount(dbo, uID, sum)
+}
+
+func changeUserCoinAmount(e execer, uID uint, sum int) error {
+	_, err := e.Exec(`
 		UPDATE user_profile
 		SET coins = coins + $1
 		WHERE user_id = $2`,
@@ -0,0 +1,173 @@
+package game
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+const (
+	RoomWaiting = "waiting" // room waits for players
+	RoomPlaying = "playing"
+
+	KickCheating = "cheating"
+	KickAdmin    = "admin"
+)
+
+//easyjson:json
+type RoomInfo struct {
+	ID      string            `json:"id"`
+	Mode    string            `json:"mode"`
+	Status  string            `json:"status"`
+	Private bool              `json:"private,omitempty"`
+	Elapsed time.Duration     `json:"elapsed"`
+	Players []*RoomPlayerInfo `json:"players"`
+}
+
+//easyjson:json
+type RoomPlayerInfo struct {
+	UID          uint `json:"uid"`
+	PlayerNum    int  `json:"playerNum,omitempty"` // 0 until the game starts
+	Team         int  `json:"team,omitempty"`
+	Score        int  `json:"score"`
+	Disconnected bool `json:"disconnected,omitempty"`
+}
+
+//easyjson:json
+type RoomsInfo []*RoomInfo
+
+// RoomDetails is the info of the room with its current state, State is nil
+// if the game has not started.
+//
+//easyjson:json
+type RoomDetails struct {
+	Info  *RoomInfo `json:"info"`
+	State *State    `json:"state,omitempty"`
+}
+
+// details returns the info of the room. Only the room itself may call it
+// with playing flag, the state of the game belongs to its loop.
+func (r *Room) details(playing bool) *RoomDetails {
+	info := &RoomInfo{
+		ID:      r.ID,
+		Mode:    r.mode,
+		Status:  RoomWaiting,
+		Private: r.code != "",
+		Players: make([]*RoomPlayerInfo, 0),
+	}
+	d := &RoomDetails{
+		Info: info,
+	}
+	if playing {
+		info.Status = RoomPlaying
+		info.Elapsed = r.engine.elapsed()
+		d.State = r.engine.state.copyState()
+	}
+	r.Players.Range(func(k, v interface{}) bool {
+		p := v.(*Player)
+		pInfo := &RoomPlayerInfo{
+			UID:          p.UserInfo.UID,
+			Disconnected: p.disconnected,
+		}
+		if d.State != nil {
+			pInfo.PlayerNum = r.engine.Players[p.GameSessionID]
+			pData := d.State.Players[pInfo.PlayerNum-1]
+			pInfo.Team = pData.Team
+			pInfo.Score = pData.Score
+		}
+		info.Players = append(info.Players, pInfo)
+		return true
+	})
+	return d
+}
+
+// inspectRoom returns the details of the room. The running room is asked
+// for them in its loop, ErrNoRoom is returned if the game is over.
+func (g *Game) inspectRoom(r *Room) (*RoomDetails, error) {
+	if !r.isStarted() {
+		return r.details(false), nil
+	}
+	reply := make(chan *RoomDetails, 1)
+	select {
+	case r.inspect <- reply:
+	case <-r.Ctx.Done():
+		return nil, ErrNoRoom
+	}
+	return <-reply, nil
+}
+
+// ListRooms returns the info of all the alive rooms.
+func ListRooms() RoomsInfo {
+	rooms := make(RoomsInfo, 0)
+	g.Rooms.Range(func(k, v interface{}) bool {
+		d, err := g.inspectRoom(v.(*Room))
+		if err == nil {
+			rooms = append(rooms, d.Info)
+		}
+		return true
+	})
+	return rooms
+}
+
+// InspectRoom returns the details of the room with given ID.
+func InspectRoom(roomID string) (*RoomDetails, error) {
+	v, ok := g.Rooms.Load(roomID)
+	if !ok {
+		return nil, ErrNoRoom
+	}
+	return g.inspectRoom(v.(*Room))
+}
+
+// TerminateRoom finishes the game in the room with given ID with AdminTerminated reason,
+// the game changes no stats, coins and rating. The room waiting for players is closed.
+func TerminateRoom(roomID string) error {
+	v, ok := g.Rooms.Load(roomID)
+	if !ok {
+		return ErrNoRoom
+	}
+	r := v.(*Room)
+	logger.Infof("room %v is terminated by admin", r.ID)
+	if g.takePrivateRoom(r) {
+		g.closePrivateRoom(r, "admin_terminated")
+		return nil
+	}
+	select {
+	case r.terminate <- struct{}{}:
+	default: // already terminating
+	}
+	return nil
+}
+
+// KickPlayer removes the player with given uID from the room with given ID. He loses
+// the game, the game is finished if there are not enough players left. Bots cannot be kicked.
+func KickPlayer(roomID string, uID uint) error {
+	if uID == BotUID {
+		return ErrKickBot
+	}
+	v, ok := g.Rooms.Load(roomID)
+	if !ok {
+		return ErrNoRoom
+	}
+	r := v.(*Room)
+	var p *Player
+	r.Players.Range(func(k, v interface{}) bool {
+		if v.(*Player).UserInfo.UID == uID {
+			p = v.(*Player)
+			return false
+		}
+		return true
+	})
+	if p == nil {
+		return ErrNoPlayer
+	}
+	logger.Infof("player %v is kicked from room %v by admin", uID, r.ID)
+	if g.leavePrivateRoom(r, p, "kicked") {
+		return nil
+	}
+	select {
+	case r.adminKicks <- p:
+	case <-r.Ctx.Done():
+		return ErrNoRoom
+	}
+	return nil
+}
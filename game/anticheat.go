@@ -0,0 +1,127 @@
+package game
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
+	"game/models"
+)
+
+const (
+	InputBurst = 3 // inputs player may save up, one input is added every frame, the rest are dropped
+
+	// Fair clients send inputs once a display frame, so on fast displays they send more
+	// messages than engine frames. Only much more messages per second are a violation.
+	MessagesPerFrame = 5
+
+	// violations are counted once per second of the impossible rate
+	FlagViolations = 5  // player with more seconds of violations gets no rewards for the game
+	KickViolations = 15 // player with more seconds of violations is kicked from the game
+
+	ViolationRate = "rate" // too many messages per second
+)
+
+// rateLimiter counts messages from the client per second.
+type rateLimiter struct {
+	since time.Time
+	count int
+}
+
+// allow counts the message and checks if the limit of messages per second is not exceeded.
+// violation is true for the first message over the limit in a second.
+func (l *rateLimiter) allow(now time.Time, limit int) (ok, violation bool) {
+	if now.Sub(l.since) >= time.Second {
+		l.since = now
+		l.count = 0
+	}
+	l.count++
+	return l.count <= limit, l.count == limit+1
+}
+
+// maxMessagesPerSecond returns the limit of messages from the client per second.
+func (r *GameRules) maxMessagesPerSecond() int {
+	return MessagesPerFrame * int(time.Second/r.MsPerFrame)
+}
+
+// violate counts the violation of the player and returns true if the player
+// has just reached KickViolations.
+func (p *Player) violate(violation string) bool {
+	metrics.AddInputViolation(violation)
+	n := atomic.AddInt32(&p.violations, 1)
+	if n == FlagViolations {
+		logger.Infof("player %v (game session %v) is flagged for %v violations", p.UserInfo.UID, p.GameSessionID, n)
+	}
+	return n == KickViolations
+}
+
+// flagged checks if the player has violated too much for fair client.
+func (p *Player) flagged() bool {
+	return atomic.LoadInt32(&p.violations) >= FlagViolations
+}
+
+// kick removes the player from the room for the reason (KickCheating or KickAdmin), he loses the game.
+func (r *Room) kick(p *Player, reason string) {
+	if !r.isCurrent(p) {
+		return
+	}
+	logger.Infof("room %v: player %v kicked for reason %v (game session %v)", r.ID, p.UserInfo.UID, reason, p.GameSessionID)
+	metrics.AddKickedPlayer(reason)
+	switch {
+	case p.isBot(): // bot has no connection
+		p.cancel()
+	case p.disconnected:
+		p.reconnectTimer.Stop()
+	default:
+		p.cancel()
+		_ = p.UserInfo.Conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "kicked"), time.Now().Add(1*time.Second))
+		p.UserInfo.Conn.Close()
+	}
+	r.leave(p)
+}
+
+// removeCheater kicks the cheating player, see removePlayer.
+func (r *Room) removeCheater(p *Player) bool {
+	return r.removePlayer(p, KickCheating)
+}
+
+// removePlayer kicks the player and finishes the game if there are not enough players
+// left. Returns true if the game is finished.
+func (r *Room) removePlayer(p *Player, reason string) bool {
+	r.kick(p, reason)
+	if r.enoughPlayers() {
+		return false
+	}
+	r.finish(&Ended{
+		Reason: Disconnected,
+		Info:   p,
+	})
+	return true
+}
+
+// flagCheaters marks results of flagged players and takes away their rewards.
+func (r *Room) flagCheaters(results map[string]*models.MatchParticipant) {
+	flag := func(p *Player) {
+		if !p.flagged() {
+			return
+		}
+		pRes := results[p.GameSessionID]
+		pRes.Flagged = true
+		pRes.Coins = 0
+		if pRes.RatingDelta > 0 {
+			pRes.RatingDelta = 0
+		}
+	}
+	r.Players.Range(func(k, v interface{}) bool {
+		flag(v.(*Player))
+		return true
+	})
+	for _, p := range r.left {
+		flag(p)
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
+)
+
+const (
+	SendBuffer       = 100 // capacity of SendMessage
+	StateQueueLimit  = 10  // states are dropped if more messages wait to be written
+	MaxDroppedStates = 100 // player is disconnected after so many states dropped in a row
+)
+
+// isState checks if the message is the state frame which may be dropped:
+// the next one replaces it anyway.
+func (m *WSMessageToSend) isState() bool {
+	return m.Status == "state" || m.Status == "delta"
+}
+
+// send puts the message to SendMessage of the player without blocking the room.
+// States are dropped if the player doesn't keep up with them, so the rest of the buffer
+// is left for control messages. The player is disconnected if he falls too far behind:
+// too many states are dropped in a row or even control message doesn't fit.
+// Returns true if the message is queued.
+func (r *Room) send(p *Player, m *WSMessageToSend) bool {
+	if p.disconnected {
+		return false
+	}
+	if m.isState() && len(p.SendMessage) >= StateQueueLimit {
+		metrics.AddDroppedMessage(m.Status)
+		p.dropped++
+		if p.dropped >= MaxDroppedStates {
+			r.dropLagging(p)
+		}
+		return false
+	}
+	select {
+	case p.SendMessage <- m:
+		if m.isState() {
+			p.dropped = 0
+		}
+		return true
+	default:
+		metrics.AddDroppedMessage(m.Status)
+		r.dropLagging(p)
+		return false
+	}
+}
+
+// dropLagging disconnects the player who doesn't read messages, he may reconnect
+// in ReconnectWindow and get the actual state. Bots can't reconnect, they just miss messages.
+func (r *Room) dropLagging(p *Player) {
+	if p.isBot() {
+		p.dropped = 0
+		return
+	}
+	logger.Infof("room %v: player %v is lagging behind (game session %v), %v states dropped",
+		r.ID, p.UserInfo.UID, p.GameSessionID, p.dropped)
+	metrics.AddLaggingPlayer()
+	p.dropped = 0
+	r.disconnect(p)
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+const (
+	DefaultBotWait = 20 * time.Second // wait in the queue before bot joins the game
+
+	BotEasy   = "easy"
+	BotNormal = "normal"
+	BotHard   = "hard"
+
+	BotUID = 0 // UID of bots in players' lists, real users have positive UIDs
+)
+
+// BotLevel is the difficulty level of bot.
+type BotLevel struct {
+	ThinkEvery int     // states between choosing new target
+	Mistakes   float64 // probability to skip the move
+	Jumps      bool    // bot jumps for high products
+}
+
+var botLevels = map[string]*BotLevel{
+	BotEasy: {
+		ThinkEvery: 15,
+		Mistakes:   0.4,
+	},
+	BotNormal: {
+		ThinkEvery: 6,
+		Mistakes:   0.15,
+		Jumps:      true,
+	},
+	BotHard: {
+		ThinkEvery: 1,
+		Jumps:      true,
+	},
+}
+
+// Bot is the server-side AI of the player: it gets states of the game
+// and moves the hero to products from its target list.
+type Bot struct {
+	Level     *BotLevel
+	playerNum int
+	target    *ProductData
+	states    int // count of got states
+	rand      *rand.Rand
+}
+
+// ParseBotLevel returns the name of bot difficulty level or error if the level is unknown.
+func ParseBotLevel(level string) (string, error) {
+	if _, ok := botLevels[level]; !ok {
+		return "", ErrUnknownBotLevel
+	}
+	return level, nil
+}
+
+// botLevelFor returns difficulty level of bot for the player with given rating.
+func botLevelFor(rating int) string {
+	switch {
+	case rating < DefaultRating-200:
+		return BotEasy
+	case rating < DefaultRating+200:
+		return BotNormal
+	default:
+		return BotHard
+	}
+}
+
+// NewBot initializes new object of Player controlled by Bot with given difficulty level.
+func NewBot(level string, rating int) *Player {
+	p := NewPlayer(&User{
+		UID: BotUID,
+	})
+	p.Rating = rating
+	p.bot = &Bot{
+		Level: botLevels[level],
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	return p
+}
+
+// isBot checks if the player is controlled by server.
+func (p *Player) isBot() bool {
+	return p.bot != nil
+}
+
+// Play reads messages sent to the bot player and sends actions to the game engine
+// in reply to states. It breaks the loop when game in room ends or the bot is kicked.
+func (p *Player) Play() {
+	for {
+		select {
+		case m := <-p.SendMessage:
+			switch payload := m.Payload.(type) {
+			case *StartInfo:
+				p.bot.playerNum = int(payload.PlayerNum)
+			case *State:
+				a, ok := p.bot.decide(payload, p.Room.rules)
+				if !ok {
+					continue
+				}
+				select {
+				case p.Room.engine.Update <- &ProcessActions{
+					From:    p.GameSessionID,
+					Actions: a,
+				}:
+				case <-p.Room.Ctx.Done():
+					return
+				case <-p.Ctx.Done():
+					return
+				}
+			}
+		case <-p.Room.Ctx.Done():
+			logger.Debugf("killed bot %v at room %v", p.GameSessionID, p.Room.ID)
+			return
+		case <-p.Ctx.Done():
+			logger.Debugf("killed kicked bot %v at room %v", p.GameSessionID, p.Room.ID)
+			return
+		}
+	}
+}
+
+// decide returns the action of the bot for the state: move to the chosen product
+// from the target list and jump if it is high. Returns false if bot does nothing.
+func (b *Bot) decide(s *State, rules *GameRules) (Actions, bool) {
+	if b.playerNum < 1 || b.playerNum > len(s.Players) {
+		return 0, false
+	}
+	b.states++
+	me := s.Players[b.playerNum-1]
+	targets := me.TargetList
+	if me.Team != 0 && me.Team <= len(s.Teams) {
+		targets = s.Teams[me.Team-1].TargetList
+	}
+	if b.target == nil || b.states%b.Level.ThinkEvery == 0 {
+		b.target = chooseTarget(me, targets, s.Products, rules)
+	}
+	if b.target == nil || b.rand.Float64() < b.Level.Mistakes {
+		return 0, false
+	}
+
+	var a Actions
+	dx := b.target.X - me.X
+	switch {
+	case dx > rules.PlayerSpeed/2:
+		a |= ActionRight
+	case dx < -rules.PlayerSpeed/2:
+		a |= ActionLeft
+	}
+	if b.Level.Jumps && math.Abs(dx) < PlayerWidth/2 && b.target.Y-me.Y > PlayerHeight {
+		a |= ActionJump
+	}
+	return a, a != 0
+}
+
+// chooseTarget returns the product from target list which falls to the ground
+// the soonest and is close enough for the player to run to it.
+func chooseTarget(me *PlayerData, targets []int, products []*ProductData, rules *GameRules) *ProductData {
+	var best *ProductData
+	for _, product := range products {
+		if product.Y < me.Y || !containsInt(targets, product.Type) {
+			continue
+		}
+		// skip products which fall before the player runs to them
+		if math.Abs(product.X-me.X)/rules.PlayerSpeed > (product.Y-me.Y)/rules.ProductSpeed {
+			continue
+		}
+		if best == nil || product.Y < best.Y {
+			best = product
+		}
+	}
+	return best
+}
+
+func containsInt(list []int, x int) bool {
+	for _, v := range list {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
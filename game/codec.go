@@ -0,0 +1,180 @@
+package game
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+
+	"github.com/gorilla/websocket"
+)
+
+// websocket subprotocols, JSON is used if client doesn't ask for subprotocol
+const (
+	ProtocolJSON   = "ketnipz-json"
+	ProtocolBinary = "ketnipz-binary" // states are packed by State.MarshalBinary
+)
+
+// Subprotocols are websocket subprotocols supported by the server.
+var Subprotocols = []string{ProtocolJSON, ProtocolBinary}
+
+// kinds of binary messages (the first byte)
+const (
+	BinaryState byte = 1
+	BinaryDelta byte = 2
+)
+
+// encode returns websocket message type and data of the message for the connection:
+// states and deltas are packed in binary if the client negotiated ProtocolBinary,
+// other messages are JSON.
+func encode(conn *websocket.Conn, m *WSMessageToSend) (int, []byte, error) {
+	if b, ok := m.Payload.(encoding.BinaryMarshaler); ok && conn.Subprotocol() == ProtocolBinary {
+		data, err := b.MarshalBinary()
+		return websocket.BinaryMessage, data, err
+	}
+	data, err := m.MarshalJSON()
+	return websocket.TextMessage, data, err
+}
+
+// binaryWriter appends little-endian values to the buffer.
+type binaryWriter struct {
+	buf []byte
+}
+
+func (w *binaryWriter) uint8(v int) {
+	w.buf = append(w.buf, byte(v))
+}
+
+func (w *binaryWriter) uint16(v int) {
+	w.buf = append(w.buf, 0, 0)
+	binary.LittleEndian.PutUint16(w.buf[len(w.buf)-2:], uint16(v))
+}
+
+func (w *binaryWriter) int16(v int) {
+	w.buf = append(w.buf, 0, 0)
+	binary.LittleEndian.PutUint16(w.buf[len(w.buf)-2:], uint16(int16(v)))
+}
+
+func (w *binaryWriter) int32(v int) {
+	w.buf = append(w.buf, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(w.buf[len(w.buf)-4:], uint32(int32(v)))
+}
+
+// coord writes coordinate with precision of 0.01 as int16.
+func (w *binaryWriter) coord(v float64) {
+	w.int16(int(math.Round(v * 100)))
+}
+
+func (w *binaryWriter) list(l []int) {
+	w.uint8(len(l))
+	for _, v := range l {
+		w.uint8(v)
+	}
+}
+
+func (w *binaryWriter) player(p *PlayerData) {
+	w.int32(p.Score)
+	w.coord(p.X)
+	w.coord(p.Y)
+	w.uint8(p.Team)
+	w.int32(p.LastInput)
+	w.list(p.TargetList)
+}
+
+func (w *binaryWriter) team(t *TeamData) {
+	w.int32(t.Score)
+	w.list(t.TargetList)
+}
+
+func (w *binaryWriter) product(p *ProductData) {
+	w.int32(p.ID)
+	w.coord(p.X)
+	w.coord(p.Y)
+	w.uint8(p.Type)
+}
+
+func (w *binaryWriter) collected(c []PointsData) {
+	w.uint16(len(c))
+	for _, p := range c {
+		w.coord(p.X)
+		w.coord(p.Y)
+		w.uint8(p.Who)
+		w.int16(p.Points)
+	}
+}
+
+// MarshalBinary packs the state for ProtocolBinary (little-endian):
+//
+//	kind uint8 (BinaryState), version int32, tick int32
+//	players count uint8, for every player:
+//	    score int32, X int16, Y int16 (coordinates * 100), team uint8, last input int32,
+//	    target list count uint8, items uint8...
+//	teams count uint8, for every team: score int32, target list count uint8, items uint8...
+//	products count uint16, for every product: id int32, X int16, Y int16, type uint8
+//	collected count uint16, for every points: X int16, Y int16, playerNum uint8, points int16
+func (s *State) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{
+		buf: make([]byte, 0, 16+len(s.Players)*20+len(s.Products)*9+len(s.Collected)*7),
+	}
+	w.uint8(int(BinaryState))
+	w.int32(s.Version)
+	w.int32(s.Tick)
+	w.uint8(len(s.Players))
+	for _, p := range s.Players {
+		w.player(p)
+	}
+	w.uint8(len(s.Teams))
+	for _, t := range s.Teams {
+		w.team(t)
+	}
+	w.uint16(len(s.Products))
+	for _, p := range s.Products {
+		w.product(p)
+	}
+	w.collected(s.Collected)
+	return w.buf, nil
+}
+
+// MarshalBinary packs the delta for ProtocolBinary (little-endian), players, teams,
+// products and collected points are packed as in State.MarshalBinary:
+//
+//	kind uint8 (BinaryDelta), version int32, base int32, tick int32
+//	changed players count uint8, for every player: playerNum uint8, player
+//	changed teams count uint8, for every team: team number uint8, team
+//	added products count uint16, products...
+//	moved products count uint16, for every product: id int32, Y int16
+//	removed products count uint16, ids int32...
+//	collected
+func (d *StateDelta) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{
+		buf: make([]byte, 0, 24+len(d.Players)*21+len(d.Added)*9+len(d.Moved)*6+len(d.Removed)*4),
+	}
+	w.uint8(int(BinaryDelta))
+	w.int32(d.Version)
+	w.int32(d.Base)
+	w.int32(d.Tick)
+	w.uint8(len(d.Players))
+	for _, p := range d.Players {
+		w.uint8(p.Num)
+		w.player(p.Data)
+	}
+	w.uint8(len(d.Teams))
+	for _, t := range d.Teams {
+		w.uint8(t.Num)
+		w.team(t.Data)
+	}
+	w.uint16(len(d.Added))
+	for _, p := range d.Added {
+		w.product(p)
+	}
+	w.uint16(len(d.Moved))
+	for _, p := range d.Moved {
+		w.int32(p.ID)
+		w.coord(p.Y)
+	}
+	w.uint16(len(d.Removed))
+	for _, id := range d.Removed {
+		w.int32(id)
+	}
+	w.collected(d.Collected)
+	return w.buf, nil
+}
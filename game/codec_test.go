@@ -0,0 +1,52 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStateMarshalBinary(t *testing.T) {
+	s := &State{
+		Version: 7,
+		Tick:    300,
+		Players: []*PlayerData{
+			{Score: 5, X: 12.34, Y: 0, LastInput: 9, TargetList: []int{1, 2}},
+		},
+		Products: []*ProductData{
+			{ID: 3, X: 50, Y: 99.5, Type: 2},
+		},
+		Collected: []PointsData{
+			{X: 10, Y: 20, Who: 1, Points: -1},
+		},
+	}
+	want := []byte{
+		byte(BinaryState),
+		0x07, 0x00, 0x00, 0x00, // version
+		0x2c, 0x01, 0x00, 0x00, // tick
+		0x01,                   // players
+		0x05, 0x00, 0x00, 0x00, // score
+		0xd2, 0x04, // X
+		0x00, 0x00, // Y
+		0x00,                   // team
+		0x09, 0x00, 0x00, 0x00, // last input
+		0x02, 0x01, 0x02, // target list
+		0x00,       // teams
+		0x01, 0x00, // products
+		0x03, 0x00, 0x00, 0x00, // id
+		0x88, 0x13, // X
+		0xde, 0x26, // Y
+		0x02,       // type
+		0x01, 0x00, // collected
+		0xe8, 0x03, // X
+		0xd0, 0x07, // Y
+		0x01,       // player number
+		0xff, 0xff, // points
+	}
+	got, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got  % x\nwant % x", got, want)
+	}
+}
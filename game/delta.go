@@ -0,0 +1,190 @@
+package game
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	KeyframeEvery = 50 // full state is sent every KeyframeEvery versions
+	StateHistory  = 64 // count of last states kept as bases for deltas
+)
+
+// StateDelta is the difference between the state of Version and the state of Base
+// version acknowledged by the client.
+//
+//easyjson:json
+type StateDelta struct {
+	Version   int              `json:"version"`
+	Base      int              `json:"base"`
+	Tick      int              `json:"tick"`
+	Players   []*ChangedPlayer `json:"players,omitempty"`
+	Teams     []*ChangedTeam   `json:"teams,omitempty"`
+	Added     []*ProductData   `json:"added,omitempty"`
+	Moved     []*MovedProduct  `json:"moved,omitempty"`
+	Removed   []int            `json:"removed,omitempty"` // IDs of products
+	Collected []PointsData     `json:"collected,omitempty"`
+}
+
+//easyjson:json
+type ChangedPlayer struct {
+	Num  int         `json:"num"` // player number
+	Data *PlayerData `json:"data"`
+}
+
+//easyjson:json
+type ChangedTeam struct {
+	Num  int       `json:"num"` // team number
+	Data *TeamData `json:"data"`
+}
+
+//easyjson:json
+type MovedProduct struct {
+	ID int     `json:"id"`
+	Y  float64 `json:"Y"` // products fall vertically
+}
+
+// diff returns the delta which turns base state into s.
+func (s *State) diff(base *State) *StateDelta {
+	d := &StateDelta{
+		Version:   s.Version,
+		Base:      base.Version,
+		Tick:      s.Tick,
+		Collected: s.Collected,
+	}
+	for i, p := range s.Players {
+		if i >= len(base.Players) || !p.equal(base.Players[i]) {
+			d.Players = append(d.Players, &ChangedPlayer{
+				Num:  i + 1,
+				Data: p,
+			})
+		}
+	}
+	for i, t := range s.Teams {
+		if i >= len(base.Teams) || t.Score != base.Teams[i].Score || !equalLists(t.TargetList, base.Teams[i].TargetList) {
+			d.Teams = append(d.Teams, &ChangedTeam{
+				Num:  i + 1,
+				Data: t,
+			})
+		}
+	}
+	baseProducts := make(map[int]*ProductData, len(base.Products))
+	for _, p := range base.Products {
+		baseProducts[p.ID] = p
+	}
+	for _, p := range s.Products {
+		b, ok := baseProducts[p.ID]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, p)
+		case b.Y != p.Y:
+			d.Moved = append(d.Moved, &MovedProduct{
+				ID: p.ID,
+				Y:  p.Y,
+			})
+		}
+		delete(baseProducts, p.ID)
+	}
+	for id := range baseProducts {
+		d.Removed = append(d.Removed, id)
+	}
+	return d
+}
+
+func (p *PlayerData) equal(o *PlayerData) bool {
+	return p.Score == o.Score && p.X == o.X && p.Y == o.Y && p.Team == o.Team && p.LastInput == o.LastInput &&
+		equalLists(p.TargetList, o.TargetList)
+}
+
+func equalLists(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ack remembers the last state version received by the player. Versions older
+// than already acknowledged one are ignored, version 0 asks for the full state.
+func (p *Player) ack(version int, resync bool) {
+	if resync {
+		atomic.StoreInt64(&p.acked, 0)
+		return
+	}
+	if int64(version) > atomic.LoadInt64(&p.acked) {
+		atomic.StoreInt64(&p.acked, int64(version))
+	}
+}
+
+// pushState saves the state as the next version and sends it to players and spectators.
+// Players who asked for lower rate get only some of versions. Players get the delta from the last state they acknowledged or the full state if
+// it is time for keyframe, they haven't acknowledged any state or it is too old.
+// Points collected in versions the player hasn't got are sent with the next one he gets.
+func (r *Room) pushState(s *State) {
+	r.version++
+	s.Version = r.version
+	r.history[s.Version%StateHistory] = s
+
+	r.Players.Range(func(k, v interface{}) bool {
+		player := v.(*Player)
+		if player.disconnected {
+			return true
+		}
+		player.collected = append(player.collected, s.Collected...)
+		if s.Version%r.snapshotDivisor(player) != 0 {
+			return true
+		}
+		ps := s
+		if len(player.collected) != len(s.Collected) { // with points of skipped versions
+			c := *s
+			c.Collected = player.collected
+			ps = &c
+		}
+		if r.send(player, r.stateMessage(player, ps)) {
+			player.collected = nil
+		}
+		return true
+	})
+	r.broadcastSpectators(&WSMessageToSend{
+		Status:  "state",
+		Payload: s,
+	})
+}
+
+// snapshotDivisor returns count of room states per state sent to the player
+// according to the rate he asked for.
+func (r *Room) snapshotDivisor(p *Player) int {
+	if p.UserInfo.Rate <= 0 {
+		return 1
+	}
+	div := int(math.Round(float64(time.Second) / float64(p.UserInfo.Rate) / float64(r.rules.SnapshotEvery)))
+	if div < 1 {
+		return 1
+	}
+	return div
+}
+
+// stateMessage returns the message with the state or delta for the player.
+func (r *Room) stateMessage(p *Player, s *State) *WSMessageToSend {
+	full := &WSMessageToSend{
+		Status:  "state",
+		Payload: s,
+	}
+	acked := int(atomic.LoadInt64(&p.acked))
+	if acked == 0 || s.Version%KeyframeEvery == 0 || s.Version-acked >= StateHistory {
+		return full
+	}
+	base := r.history[acked%StateHistory]
+	if base == nil || base.Version != acked {
+		return full
+	}
+	return &WSMessageToSend{
+		Status:  "delta",
+		Payload: s.diff(base),
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestStateDiff(t *testing.T) {
+	base := &State{
+		Version: 10,
+		Tick:    100,
+		Players: []*PlayerData{
+			{Score: 1, X: 10, Y: 0, Team: 1, TargetList: []int{}},
+			{Score: 2, X: 20, Y: 0, Team: 2, TargetList: []int{}},
+		},
+		Teams: []*TeamData{
+			{Score: 1, TargetList: []int{1, 2}},
+			{Score: 2, TargetList: []int{3, 4}},
+		},
+		Products: []*ProductData{
+			{ID: 1, X: 10, Y: 90, Type: 1},
+			{ID: 2, X: 20, Y: 80, Type: 2},
+			{ID: 4, X: 40, Y: 60, Type: 4},
+		},
+	}
+	s := base.copyState()
+	s.Version = 12
+	s.Tick = 102
+	s.Players[1].X = 21
+	s.Teams[0].TargetList = []int{2}
+	s.Products[0].Y = 89
+	s.Products = append(s.Products[:1], s.Products[2:]...) // product 2 is caught
+	s.Products = append(s.Products, &ProductData{ID: 5, X: 50, Y: 100, Type: 5})
+	s.Collected = []PointsData{{X: 20, Y: 80, Who: 1, Points: 3}}
+
+	d := s.diff(base)
+	if d.Version != 12 || d.Base != 10 || d.Tick != 102 {
+		t.Errorf("got version %v, base %v, tick %v, want 12, 10, 102", d.Version, d.Base, d.Tick)
+	}
+	if len(d.Players) != 1 || d.Players[0].Num != 2 || d.Players[0].Data.X != 21 {
+		t.Errorf("changed players: got %+v, want only player 2", d.Players)
+	}
+	if len(d.Teams) != 1 || d.Teams[0].Num != 1 {
+		t.Errorf("changed teams: got %+v, want only team 1", d.Teams)
+	}
+	if len(d.Added) != 1 || d.Added[0].ID != 5 {
+		t.Errorf("added products: got %+v, want product 5", d.Added)
+	}
+	if !reflect.DeepEqual(d.Moved, []*MovedProduct{{ID: 1, Y: 89}}) {
+		t.Errorf("moved products: got %+v, want product 1 to 89", d.Moved)
+	}
+	sort.Ints(d.Removed)
+	if !reflect.DeepEqual(d.Removed, []int{2}) {
+		t.Errorf("removed products: got %v, want [2]", d.Removed)
+	}
+	if !reflect.DeepEqual(d.Collected, s.Collected) {
+		t.Errorf("collected: got %v, want %v", d.Collected, s.Collected)
+	}
+
+	if d := base.copyState().diff(base); len(d.Players)+len(d.Teams)+len(d.Added)+len(d.Moved)+len(d.Removed) != 0 {
+		t.Errorf("delta of equal states is not empty: %+v", d)
+	}
+}
+
+func TestStateDeltaMarshalBinary(t *testing.T) {
+	d := &StateDelta{
+		Version: 12,
+		Base:    10,
+		Tick:    102,
+		Moved:   []*MovedProduct{{ID: 1, Y: 89}},
+		Removed: []int{2},
+	}
+	want := []byte{
+		byte(BinaryDelta),
+		0x0c, 0x00, 0x00, 0x00, // version
+		0x0a, 0x00, 0x00, 0x00, // base
+		0x66, 0x00, 0x00, 0x00, // tick
+		0x00,       // players
+		0x00,       // teams
+		0x00, 0x00, // added
+		0x01, 0x00, // moved
+		0x01, 0x00, 0x00, 0x00, // id
+		0xc4, 0x22, // Y
+		0x01, 0x00, // removed
+		0x02, 0x00, 0x00, 0x00, // id
+		0x00, 0x00, // collected
+	}
+	got, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  % x\nwant % x", got, want)
+	}
+}
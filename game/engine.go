@@ -9,9 +9,13 @@ import (
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
 )
 
+// default values of GameRules
 const (
-	MsPerFrame = 20 * time.Millisecond // 50 fps
-	GameTime   = 30 * time.Second
+	PlayersCount = 2
+
+	MsPerFrame    = 20 * time.Millisecond // 50 fps
+	SnapshotEvery = MsPerFrame            // states are sent on every frame
+	GameTime      = 30 * time.Second
 
 	TargetCount        = 4
 	TargetVariaty      = 6
@@ -35,15 +39,19 @@ const (
 //easyjson:json
 type PlayerData struct {
 	Score      int     `json:"score"`
-	X          float64 `json:"X"`          // 0-100
-	Y          float64 `json:"Y"`          // 0-100
-	TargetList []int   `json:"targetList"` // 1-6
+	X          float64 `json:"X"`                   // 0-100
+	Y          float64 `json:"Y"`                   // 0-100
+	TargetList []int   `json:"targetList"`          // 1-6, empty in team mode
+	Team       int     `json:"team,omitempty"`      // 1-2 in team mode
+	LastInput  int     `json:"lastInput,omitempty"` // seq of the last input applied
 	speedY     float64 // jump
 	jumps      bool
+	inputs     int // inputs player can do now, refilled every frame up to InputBurst
 }
 
 //easyjson:json
 type ProductData struct {
+	ID    int     `json:"id"`
 	X     float64 `json:"X"`    // 0-100
 	Y     float64 `json:"Y"`    // 0-100
 	Type  int     `json:"type"` // 1-6
@@ -54,142 +62,179 @@ type ProductData struct {
 type PointsData struct {
 	X      float64 `json:"X"`         // 0-100
 	Y      float64 `json:"Y"`         // 0-100
-	Who    int     `json:"playerNum"` // 1 or 2 (player number who catched)
+	Who    int     `json:"playerNum"` // 1-6 (player number who catched)
 	Points int     `json:"points"`    // points of catched item (-1, +3)
 }
 
 //easyjson:json
 type State struct {
-	Player1   *PlayerData    `json:"player1"`
-	Player2   *PlayerData    `json:"player2"`
+	Version   int            `json:"version,omitempty"` // number of the state sent by the room
+	Tick      int            `json:"tick"`              // count of engine updates
+	Players   []*PlayerData  `json:"players"`           // by player numbers
+	Teams     []*TeamData    `json:"teams,omitempty"`   // by team numbers in team mode
 	Products  []*ProductData `json:"products,omitempty"`
-	Collected []PointsData   `json:"collected,omitempty"`
+	Collected []PointsData   `json:"collected,omitempty"` // since the previous state sent
 }
 
 // Actions are move `LEFT`, `RIGHT` or `JUMP`
-type Actions int // byte mask
+type Actions int // bit flags ActionLeft, ActionRight, ActionJump
 
 type Engine struct {
 	Players map[string]int
+	Seed    int64
 
 	Update chan *ProcessActions
 
-	timer      *time.Timer
-	ticker     *time.Ticker
-	randomizer *time.Ticker
-	state      *State
-	status     *Ended
+	rules  *GameRules
+	mode   string
+	ticker *time.Ticker
+	tick   int // count of state updates
+	rand   *rand.Rand
+	state  *State
+	lastID int // ID of the last product
+	status *Ended
 }
 
 // updateState updates game room state (products move, players and products collide,
 // products disappear, points appear, etc.).
 func (e *Engine) updateState() {
+	e.tick++
+	e.state.Tick = e.tick
+	if e.tick%int(e.rules.TargetRandomsEvery/e.rules.MsPerFrame) == 0 {
+		e.randomTarget()
+	}
 	s := e.state
-	player1 := s.Player1
-	player2 := s.Player2
-	s.Collected = s.Collected[:0] // clear points on screen
 	for i := len(s.Products) - 1; i >= 0; i-- {
 		s.Products[i].Y = math.Round((s.Products[i].Y-s.Products[i].speed)*100) / 100
-		p1caught := objectsCollide(s.Products[i], player1)
-		p2caught := objectsCollide(s.Products[i], player2)
-		if p1caught {
-			e.countPoints(s.Products[i], player1, 1)
-		}
-		if p2caught {
-			e.countPoints(s.Products[i], player2, 2)
+		caught := false
+		teamCaught := make([]bool, len(s.Teams))
+		for num, player := range s.Players {
+			if objectsCollide(s.Products[i], player) {
+				caught = true
+				if player.Team != 0 {
+					if teamCaught[player.Team-1] { // teammate has already caught it
+						continue
+					}
+					teamCaught[player.Team-1] = true
+				}
+				e.countPoints(s.Products[i], player, num+1)
+			}
 		}
 		// delete if caught or fade out
-		if (p1caught || p2caught) || (s.Products[i].Y < ProductMinY) {
+		if caught || (s.Products[i].Y < ProductMinY) {
 			s.Products = append(s.Products[:i], s.Products[i+1:]...)
 		}
 	}
-	if player1.jumps {
-		player1.performJump()
-	}
-	if player2.jumps {
-		player2.performJump()
+	for _, player := range s.Players {
+		if player.jumps {
+			player.performJump(e.rules.PlayerGravity)
+		}
+		if player.inputs < InputBurst {
+			player.inputs++
+		}
 	}
-	if len(player1.TargetList) == 0 {
-		player1.TargetList = generateNewProductList()
+	for _, player := range s.Players {
+		if player.Team == 0 && len(player.TargetList) == 0 {
+			player.TargetList = e.generateNewProductList()
+		}
 	}
-	if len(player2.TargetList) == 0 {
-		player2.TargetList = generateNewProductList()
+	for _, team := range s.Teams {
+		if len(team.TargetList) == 0 {
+			team.TargetList = e.generateNewProductList()
+		}
 	}
 }
 
+// dueTicks returns count of state updates which should be done when the time has passed since the start.
+func (e *Engine) dueTicks(passed time.Duration) int {
+	return int(passed / e.rules.MsPerFrame)
+}
+
+// timeOver checks if the game time is over.
+func (e *Engine) timeOver() bool {
+	return e.tick >= int(e.rules.GameTime/e.rules.MsPerFrame)
+}
+
+// elapsed returns game time passed since the start.
+func (e *Engine) elapsed() time.Duration {
+	return time.Duration(e.tick) * e.rules.MsPerFrame
+}
+
 // randomTarget randoms new target (product) and appends it to the slice of products.
 func (e *Engine) randomTarget() {
+	e.lastID++
 	t := &ProductData{
-		X:     math.Round((rand.Float64()*90+5)*100) / 100, // [5, 95]
+		ID:    e.lastID,
+		X:     math.Round((e.rand.Float64()*90+5)*100) / 100, // [5, 95]
 		Y:     100,
-		Type:  rand.Intn(TargetVariaty) + 1,
-		speed: math.Round((ProductSpeed+rand.Float64()*ProductSpeed/2)*100) / 100,
+		Type:  e.rand.Intn(e.rules.TargetVariaty) + 1,
+		speed: math.Round((e.rules.ProductSpeed+e.rand.Float64()*e.rules.ProductSpeed/2)*100) / 100,
 	}
 	logger.Infof("new product is %v", t)
 	e.state.Products = append(e.state.Products, t)
 }
 
 // doAction updates player's position: moves him left, right or performs jump.
-func (e *Engine) doAction(a *ProcessActions) {
+// Numbered inputs older than the last applied one are dropped as well as inputs
+// over the budget of the player: a fast client sends more inputs than frames.
+func (e *Engine) doAction(a *ProcessActions) error {
 	uGameID := a.From
 	playerNumber := e.Players[uGameID]
-	var player *PlayerData
-	if playerNumber == 1 {
-		player = e.state.Player1
-	} else {
-		player = e.state.Player2
+	if playerNumber == 0 {
+		logger.Errorf("action from unknown player %v", uGameID)
+		return ErrNoPlayer
 	}
-	switch a.Actions {
-	case 1:
-		logger.Debugf("the hero %v moves right", uGameID)
-		player.X = math.Min(100, math.Round((player.X+PlayerSpeed)*100)/100)
-	case 10, 111:
+	player := e.state.Players[playerNumber-1]
+	if a.Seq != 0 && a.Seq <= player.LastInput {
+		logger.Debugf("dropped outdated input %v of the hero %v", a.Seq, uGameID)
+		return nil
+	}
+	if err := a.Actions.Validate(); err != nil {
+		logger.Errorf("unknown actions from %v: %v", uGameID, a.Actions)
+		return err
+	}
+	if player.inputs == 0 {
+		logger.Debugf("the hero %v is out of input budget", uGameID)
+		return nil
+	}
+	player.inputs--
+	if a.Seq != 0 {
+		player.LastInput = a.Seq
+	}
+	if a.Has(ActionJump) && !player.jumps {
 		logger.Debugf("the hero %v jumps", uGameID)
-		if !player.jumps {
-			player.speedY = PlayerJumpSpeed
-			player.jumps = true
-		}
-	case 100:
+		player.speedY = e.rules.PlayerJumpSpeed
+		player.jumps = true
+	}
+	// left and right at the same time cancel each other
+	switch {
+	case a.Has(ActionRight) && !a.Has(ActionLeft):
+		logger.Debugf("the hero %v moves right", uGameID)
+		player.X = math.Min(100, math.Round((player.X+e.rules.PlayerSpeed)*100)/100)
+	case a.Has(ActionLeft) && !a.Has(ActionRight):
 		logger.Debugf("the hero %v moves left", uGameID)
-		player.X = math.Max(0, math.Round((player.X-PlayerSpeed)*100)/100)
-	case 11:
-		logger.Debugf("the hero %v moves right and jumps", uGameID)
-		if !player.jumps {
-			player.speedY = PlayerJumpSpeed
-			player.jumps = true
-		}
-		player.X = math.Min(100, math.Round((player.X+PlayerSpeed)*100)/100)
-	case 110:
-		logger.Debugf("the hero %v moves left and jumps", uGameID)
-		if !player.jumps {
-			player.speedY = PlayerJumpSpeed
-			player.jumps = true
-		}
-		player.X = math.Max(0, math.Round((player.X-PlayerSpeed)*100)/100)
-	case 0, 101: // should not be sent from front-end
-		logger.Debugf("the hero %v stands still, nothing to do", uGameID)
-	default:
-		logger.Errorf("unknown mask from %v: %v", uGameID, a.Actions)
+		player.X = math.Max(0, math.Round((player.X-e.rules.PlayerSpeed)*100)/100)
 	}
+	return nil
 }
 
 // generateNewProductList returns new target list of random products for player.
-func generateNewProductList() []int {
-	list := make([]int, 0, TargetCount)
-	if TargetVariaty >= TargetCount { // list has only unique items
-		variaty := make([]int, 0, TargetVariaty)
-		for i := 0; i < TargetVariaty; i++ {
+func (e *Engine) generateNewProductList() []int {
+	list := make([]int, 0, e.rules.TargetCount)
+	if e.rules.TargetVariaty >= e.rules.TargetCount { // list has only unique items
+		variaty := make([]int, 0, e.rules.TargetVariaty)
+		for i := 0; i < e.rules.TargetVariaty; i++ {
 			variaty = append(variaty, i+1)
 		}
-		for i := 0; i < TargetCount; i++ {
-			pos := rand.Intn(len(variaty))
+		for i := 0; i < e.rules.TargetCount; i++ {
+			pos := e.rand.Intn(len(variaty))
 			item := variaty[pos]
 			list = append(list, item)
 			variaty = append(variaty[:pos], variaty[pos+1:]...)
 		}
 	} else { // variaty is less than target item count so list has repeatable items
-		for i := 0; i < TargetCount; i++ {
-			list = append(list, rand.Intn(TargetVariaty)+1) // [1, TargetVariaty]
+		for i := 0; i < e.rules.TargetCount; i++ {
+			list = append(list, e.rand.Intn(e.rules.TargetVariaty)+1) // [1, TargetVariaty]
 		}
 	}
 	return list
@@ -219,10 +264,10 @@ func objectsCollide(product *ProductData, player *PlayerData) bool {
 	return XColl && YColl
 }
 
-// performJump moves player in Y dimension and reduces his Y-speed.
-func (player *PlayerData) performJump() {
+// performJump moves player in Y dimension and reduces his Y-speed by gravity.
+func (player *PlayerData) performJump(gravity float64) {
 	player.Y = math.Round((player.Y+player.speedY)*100) / 100
-	player.speedY = math.Round((player.speedY-PlayerGravity)*100) / 100
+	player.speedY = math.Round((player.speedY-gravity)*100) / 100
 	if player.Y <= PlayerBaseY {
 		player.speedY = 0
 		player.jumps = false
@@ -233,13 +278,26 @@ func (player *PlayerData) performJump() {
 // countPoints checks if the product is in player's target list and adds
 // PlayerSuccessPoints to his score and deletes from the list if it is or
 // reduces the score by PlayerFailurePoints. Points are displayed at the product location.
+// In team mode the target list is shared by teammates and points are added to the team score too.
 func (e *Engine) countPoints(caught *ProductData, player *PlayerData, playerNum int) {
+	list := &player.TargetList
+	var team *TeamData
+	if player.Team != 0 {
+		team = e.state.Teams[player.Team-1]
+		list = &team.TargetList
+	}
+	addPoints := func(points int) {
+		player.Score += points
+		if team != nil {
+			team.Score += points
+		}
+	}
 	itemIsInList := false
-	for i := len(player.TargetList) - 1; i >= 0; i-- {
-		if caught.Type == player.TargetList[i] {
-			player.Score += PlayerSuccessPoints
-			// delete from player's target list
-			player.TargetList = append(player.TargetList[:i], player.TargetList[i+1:]...)
+	for i := len(*list) - 1; i >= 0; i-- {
+		if caught.Type == (*list)[i] {
+			addPoints(e.rules.PlayerSuccessPoints)
+			// delete from target list
+			*list = append((*list)[:i], (*list)[i+1:]...)
 			itemIsInList = true
 		}
 	}
@@ -249,39 +307,56 @@ func (e *Engine) countPoints(caught *ProductData, player *PlayerData, playerNum
 		Who: playerNum,
 	}
 	if itemIsInList {
-		points.Points = PlayerSuccessPoints
+		points.Points = e.rules.PlayerSuccessPoints
 		e.state.Collected = append(e.state.Collected, points)
 		logger.Infof("player %v caught necessary product %v at (%v, %v)", playerNum, caught.Type, caught.X, caught.Y)
 	} else {
-		player.Score += PlayerFailurePoints
-		points.Points = PlayerFailurePoints
+		addPoints(e.rules.PlayerFailurePoints)
+		points.Points = e.rules.PlayerFailurePoints
 		e.state.Collected = append(e.state.Collected, points)
 		logger.Infof("player %v caught wrong product %v at (%v, %v)", playerNum, caught.Type, caught.X, caught.Y)
 	}
 }
 
+// snapshot returns the copy of the state to send to clients and clears points
+// collected since the previous snapshot, so no points are lost between snapshots.
+func (e *Engine) snapshot() *State {
+	s := e.state.copyState()
+	e.state.Collected = e.state.Collected[:0]
+	return s
+}
+
 // copyState returns deep copy of state
 func (src *State) copyState() *State {
 	dst := &State{
-		Player1: &PlayerData{
-			Score:      src.Player1.Score,
-			X:          src.Player1.X,
-			Y:          src.Player1.Y,
-			TargetList: make([]int, len(src.Player1.TargetList)),
-			speedY:     src.Player1.speedY,
-		},
-		Player2: &PlayerData{
-			Score:      src.Player2.Score,
-			X:          src.Player2.X,
-			Y:          src.Player2.Y,
-			TargetList: make([]int, len(src.Player2.TargetList)),
-			speedY:     src.Player2.speedY,
-		},
+		Version:   src.Version,
+		Tick:      src.Tick,
+		Players:   make([]*PlayerData, 0, len(src.Players)),
+		Teams:     make([]*TeamData, 0, len(src.Teams)),
 		Products:  make([]*ProductData, 0, len(src.Products)),
 		Collected: make([]PointsData, len(src.Collected)),
 	}
-	copy(dst.Player1.TargetList, src.Player1.TargetList)
-	copy(dst.Player2.TargetList, src.Player2.TargetList)
+	for _, v := range src.Players {
+		p := &PlayerData{
+			Score:      v.Score,
+			X:          v.X,
+			Y:          v.Y,
+			TargetList: make([]int, len(v.TargetList)),
+			Team:       v.Team,
+			LastInput:  v.LastInput,
+			speedY:     v.speedY,
+		}
+		copy(p.TargetList, v.TargetList)
+		dst.Players = append(dst.Players, p)
+	}
+	for _, v := range src.Teams {
+		t := &TeamData{
+			Score:      v.Score,
+			TargetList: make([]int, len(v.TargetList)),
+		}
+		copy(t.TargetList, v.TargetList)
+		dst.Teams = append(dst.Teams, t)
+	}
 	for _, v := range src.Products {
 		p := &ProductData{}
 		*p = *v
@@ -292,37 +367,72 @@ func (src *State) copyState() *State {
 	return dst
 }
 
-// NewEngine initializes new object of Engine with given room and players.
-func NewEngine(r *Room, p1, p2 *Player) (*Engine, error) {
-	if p1 == nil || p2 == nil {
+// NewEngine initializes new object of Engine with given room, rules, game mode and players
+// (player numbers are their positions in the slice, in team mode teams are given by teamOf).
+// All random of the game is generated from the seed, so the same seed, rules and
+// actions give the same game.
+func NewEngine(r *Room, rules *GameRules, mode string, players []*Player, seed int64) (*Engine, error) {
+	if len(players) < MinPlayers || len(players) > MaxPlayers {
 		return nil, fmt.Errorf("players' data is not valid")
 	}
-	ge := &Engine{
-		Players: make(map[string]int),
-		Update:  make(chan *ProcessActions, 100),
-		state:   NewInitialState(),
+	if mode == ModeTeam && len(players) != TeamSize*TeamsCount {
+		return nil, fmt.Errorf("players' data is not valid")
 	}
-
-	ge.Players[p1.GameSessionID] = 1
-	ge.Players[p2.GameSessionID] = 2
+	ge := newEngine(rules, mode, seed)
+	for i, p := range players {
+		if p == nil {
+			return nil, fmt.Errorf("players' data is not valid")
+		}
+		ge.Players[p.GameSessionID] = i + 1
+	}
+	ge.state = ge.NewInitialState()
 
 	return ge, nil
 }
 
-// NewInitialState returns new state initialized with default values.
-func NewInitialState() *State {
-	return &State{
-		Player1: &PlayerData{
-			X:          25,
-			Y:          PlayerBaseY,
-			TargetList: generateNewProductList(),
-		},
-		Player2: &PlayerData{
-			X:          75,
-			Y:          PlayerBaseY,
-			TargetList: generateNewProductList(),
-		},
+// newEngine initializes new object of Engine without players and state.
+func newEngine(rules *GameRules, mode string, seed int64) *Engine {
+	return &Engine{
+		Players: make(map[string]int),
+		Seed:    seed,
+		rules:   rules,
+		mode:    mode,
+		Update:  make(chan *ProcessActions, 100),
+		rand:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewInitialState returns new state initialized with default values
+// for all players of the engine. Players stand evenly along the field.
+// In team mode target lists are given to teams instead of players.
+func (e *Engine) NewInitialState() *State {
+	n := len(e.Players)
+	s := &State{
+		Players:   make([]*PlayerData, 0, n),
 		Products:  make([]*ProductData, 0, 16),
 		Collected: make([]PointsData, 0, 4),
 	}
+	if e.mode == ModeTeam {
+		s.Teams = make([]*TeamData, 0, TeamsCount)
+		for i := 0; i < TeamsCount; i++ {
+			s.Teams = append(s.Teams, &TeamData{
+				TargetList: e.generateNewProductList(),
+			})
+		}
+	}
+	for i := 0; i < n; i++ {
+		player := &PlayerData{
+			X:      100 * (float64(i) + 0.5) / float64(n),
+			Y:      PlayerBaseY,
+			inputs: InputBurst,
+		}
+		if e.mode == ModeTeam {
+			player.Team = teamOf(i + 1)
+			player.TargetList = []int{}
+		} else {
+			player.TargetList = e.generateNewProductList()
+		}
+		s.Players = append(s.Players, player)
+	}
+	return s
 }
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// runEngine plays the game of the mode with given seed for ticks frames, every player
+// sends scripted inputs, and returns JSON of the state after every frame.
+func runEngine(t *testing.T, mode string, seed int64, ticks int) [][]byte {
+	rules := DefaultGameRules()
+	e := newEngine(rules, mode, seed)
+	players := rules.PlayersCount
+	if mode == ModeTeam {
+		players = TeamSize * TeamsCount
+	}
+	for i := 0; i < players; i++ {
+		e.Players[fmt.Sprintf("player%v", i+1)] = i + 1
+	}
+	e.state = e.NewInitialState()
+
+	states := make([][]byte, 0, ticks)
+	for tick := 1; tick <= ticks; tick++ {
+		for i := 0; i < players; i++ {
+			// every player has his own pattern: runs to one side, turns back and jumps sometimes
+			var a Actions
+			if (tick/(40+i*7))%2 == 0 {
+				a |= ActionRight
+			} else {
+				a |= ActionLeft
+			}
+			if tick%(25+i*3) == 0 {
+				a |= ActionJump
+			}
+			err := e.doAction(&ProcessActions{
+				From:    fmt.Sprintf("player%v", i+1),
+				Seq:     tick,
+				Actions: a,
+			})
+			if err != nil {
+				t.Fatalf("tick %v: action of player %v: %v", tick, i+1, err)
+			}
+		}
+		e.updateState()
+		j, err := e.state.copyState().MarshalJSON()
+		if err != nil {
+			t.Fatalf("tick %v: %v", tick, err)
+		}
+		states = append(states, j)
+	}
+	return states
+}
+
+func TestEngineDeterminism(t *testing.T) {
+	ticks := int(DefaultGameRules().GameTime / MsPerFrame)
+	for _, mode := range []string{ModeSolo, ModeTeam} {
+		t.Run(mode, func(t *testing.T) {
+			first := runEngine(t, mode, 42, ticks)
+			second := runEngine(t, mode, 42, ticks)
+			for i := range first {
+				if !bytes.Equal(first[i], second[i]) {
+					t.Fatalf("states differ at tick %v:\n%s\n%s", i+1, first[i], second[i])
+				}
+			}
+
+			other := runEngine(t, mode, 43, ticks)
+			if bytes.Equal(first[len(first)-1], other[len(other)-1]) {
+				t.Errorf("games with different seeds end with the same state")
+			}
+		})
+	}
+}
@@ -5,4 +5,17 @@ import "fmt"
 var (
 	ErrMaxRooms  = fmt.Errorf("max count of rooms")
 	ErrIsPlaying = fmt.Errorf("acc is in game now")
+	ErrNoRoom    = fmt.Errorf("room not found")
+	ErrNoReplay  = fmt.Errorf("replay not found")
+
+	ErrUnknownMode   = fmt.Errorf("unknown game mode")
+	ErrBadInviteCode = fmt.Errorf("invite code is wrong or expired")
+
+	ErrUnknownBotLevel = fmt.Errorf("unknown bot difficulty level")
+	ErrKickBot         = fmt.Errorf("bots cannot be kicked")
+
+	ErrBadMessage         = fmt.Errorf("message is not valid")
+	ErrUnsupportedVersion = fmt.Errorf("unsupported message version")
+	ErrUnknownActions     = fmt.Errorf("unknown actions")
+	ErrNoPlayer           = fmt.Errorf("unknown player")
 )
@@ -1,7 +1,7 @@
 package game
 
 import (
-	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -18,6 +18,7 @@ import (
 const (
 	MaxRooms = 100500
 
+	// default values of GameRules
 	WinnerCoinsCoefficient = 0.5
 	LoserCoinsAmount       = 3
 	DrawCoinsCoefficient   = 0.3
@@ -30,279 +31,368 @@ type Game struct {
 	Total  int
 	TotalM *sync.Mutex
 
+	Matchmaker *Matchmaker
+
+	invites  map[string]*Room // private rooms waiting for players by invite codes
+	invitesM *sync.Mutex
+
 	Register  chan *User
+	Leave     chan *Player // players who disconnected while waiting for the game
+	Cancel    chan *Player // players who don't want to wait for the game anymore
 	CloseRoom chan *Room
 
-	dm *db.DatabaseManager
+	dm     *db.DatabaseManager
+	outbox *Outbox
+	rules  *GameRules
+
+	botWait      time.Duration // wait in the queue before bot joins, 0 to disable bots
+	matchTimeout time.Duration // wait in the queue before player is sent away, 0 to wait forever
+
+	draining int32 // atomic, 1 when server is shutting down
 }
 
-// Run listens to channel Register (processes User) and CloseRoom (closes room with finished game).
+// Run listens to channel Register (processes User), Leave and Cancel (evict waiting players),
+// CloseRoom (closes room with finished game) and matches waiting players.
 func (g *Game) Run() {
+	go g.retryResults()
+	matchTicker := time.NewTicker(MatchmakingEvery)
+	defer matchTicker.Stop()
 	for {
 		select {
+		case <-matchTicker.C:
+			g.matchPlayers()
 		case u := <-g.Register:
 			logger.Infof("game got new ws connection, user %v, session_id %v", u.UID, u.SessionID)
 			go g.processUser(u)
+		case p := <-g.Leave:
+			g.evict(p, "")
+		case p := <-g.Cancel:
+			g.evict(p, "cancelled")
 		case r := <-g.CloseRoom:
 			g.saveResults(r)
-			rID := r.ID
-			g.Rooms.Delete(rID)
-			g.TotalM.Lock()
-			g.Total--
-			metrics.SubtractRoomFromCounter()
-			g.TotalM.Unlock()
-			logger.Infof("closed room %v, total %v", rID, g.Total)
+			g.removeRoom(r)
 		}
 	}
 }
 
-// processUser processes User to Room.
+// removeRoom deletes the closed room from the Game.
+func (g *Game) removeRoom(r *Room) {
+	g.Rooms.Delete(r.ID)
+	g.TotalM.Lock()
+	g.Total--
+	metrics.SubtractRoomFromCounter()
+	g.TotalM.Unlock()
+	logger.Infof("closed room %v, total %v", r.ID, g.total())
+}
+
+// processUser returns User to his room if he was disconnected from it, puts him to the private room
+// or processes him to matchmaking queue.
 func (g *Game) processUser(u *User) {
 	p := NewPlayer(u)
-	r, err := g.findRoom(p)
+	if r, old := g.findPlayerRoom(u.UID); r != nil {
+		if g.isWaitingPrivate(r) {
+			logger.Infof("player with id %v is already waiting in private room", u.UID)
+			rejectUser(u, "playing")
+			return
+		}
+		p.GameSessionID = old.GameSessionID
+		select {
+		case r.Reconnect <- p:
+			return
+		case <-r.Ctx.Done():
+			// game is over, so user can search for a new one
+			p = NewPlayer(u)
+		}
+	}
+	if g.Draining() {
+		logger.Infof("player with id %v rejected: server is shutting down", u.UID)
+		rejectUser(u, "server_shutdown")
+		return
+	}
+	if g.Matchmaker.Contains(u.UID) {
+		logger.Infof("player with id %v is already playing", u.UID)
+		rejectUser(u, "playing")
+		return
+	}
+
+	rating, err := database.GetUserRating(g.dm, u.UID)
 	if err != nil {
-		switch err {
-		case ErrMaxRooms:
-			logger.Error(err)
-		case ErrIsPlaying:
+		logger.Errorf("failed to get rating of player %v, using default: %v", u.UID, err)
+		rating = DefaultRating
+	}
+	p.Rating = rating
+
+	switch {
+	case u.Private:
+		err = g.createPrivateRoom(p, u.Mode)
+		if err != nil {
+			logger.Errorf("failed to create private room for player %v: %v", u.UID, err)
+			rejectUser(u, "error")
+			return
+		}
+	case u.Bot != "":
+		err = g.startBotGame(p, u.Mode, u.Bot)
+		if err != nil {
+			logger.Errorf("failed to start game with bot for player %v: %v", u.UID, err)
+			rejectUser(u, "error")
+			return
+		}
+	case u.Code != "":
+		err = g.joinPrivateRoom(p, u.Code)
+		if err != nil {
+			logger.Infof("player %v tried to join private room with bad code %v", u.UID, u.Code)
+			rejectUser(u, "bad_code")
+			return
+		}
+	default:
+		err = g.queue(p)
+		if err != nil {
 			logger.Infof("player with id %v is already playing", u.UID)
-			m := &WSMessageToSend{
-				Status: "playing",
-			}
-			j, err := m.MarshalJSON()
-			if err != nil {
-				logger.Error(err)
-			}
-			_ = u.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
-			_ = u.Conn.WriteMessage(websocket.TextMessage, j)
-			_ = u.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
-			_ = u.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-			time.Sleep(1 * time.Second)
-			u.Conn.Close()
+			rejectUser(u, "playing")
+			return
 		}
-		return
 	}
+	go p.Listen()
+	go p.heartbeat()
+}
+
+// queue puts the player to matchmaking queue. ErrIsPlaying is returned if the user
+// has been queued by another connection meanwhile.
+func (g *Game) queue(p *Player) error {
+	u := p.UserInfo
+
+	m := &WSMessageToSend{
+		Status: "queued",
+	}
+	j, err := m.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+	}
+	_ = u.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+	_ = u.Conn.WriteMessage(websocket.TextMessage, j)
 
-	r.Players.Store(p.GameSessionID, p)
-	r.TotalM.Lock()
-	r.Total++
-	r.TotalM.Unlock()
-	p.Room = r
-	go p.Send()
-	p.SendMessage <- &WSMessageToSend{
-		Status: "connected",
+	err = g.Matchmaker.PushUnique(&Ticket{
+		Player: p,
+		Mode:   u.Mode,
+		Rating: p.Rating,
+		Since:  time.Now(),
+	})
+	if err != nil {
+		return err
 	}
-	logger.Infof("player %v (game session %v) joined room %v", p.UserInfo.UID, p.GameSessionID, r.ID)
+	logger.Infof("player %v (game session %v, rating %v) queued in %v mode, waiting %v",
+		u.UID, p.GameSessionID, p.Rating, u.Mode, g.Matchmaker.Len())
+	return nil
+}
 
-	if r.Total == MaxPlayers {
-		go r.Run()
+// rejectUser sends the status to User and closes his connection.
+func rejectUser(u *User, status string) {
+	m := &WSMessageToSend{
+		Status: status,
 	}
+	j, err := m.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+	}
+	_ = u.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+	_ = u.Conn.WriteMessage(websocket.TextMessage, j)
+	_ = u.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+	_ = u.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	time.Sleep(1 * time.Second)
+	u.Conn.Close()
 }
 
-// findRoom searches for free room or creates new room.
-func (g *Game) findRoom(p *Player) (*Room, error) {
+// findPlayerRoom searches for the room where user with given uID plays
+// and returns the room with his player.
+func (g *Game) findPlayerRoom(uID uint) (*Room, *Player) {
 	var r *Room
-	var err error
+	var p *Player
 	g.Rooms.Range(func(k, v interface{}) bool {
 		rv := v.(*Room)
 		rv.Players.Range(func(k, v interface{}) bool {
 			pv := v.(*Player)
-			if pv.UserInfo.UID == p.UserInfo.UID {
-				err = ErrIsPlaying
+			if pv.UserInfo.UID == uID {
+				r, p = rv, pv
 				return false
 			}
 			return true
 		})
-		if rv.Total < MaxPlayers {
-			// TODO: kick dead players
-			// rv.Players.Range(func(k, v interface{}) bool {
-			// 	pv := v.(*Player)
-			// 	logger.Info(pv.UserInfo.Conn.RemoteAddr(), " ", pv.UserInfo.SessionID)
-			// 	if err := pv.UserInfo.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-			// 		if err := pv.UserInfo.Conn.WriteControl(
-			// 			websocket.PingMessage, []byte{}, time.Now().Add(2*time.Second)); err != nil {
-			// 		logger.Infof("PING ERROR")
-			// 		r.Players.Delete(pv.GameSessionID)
-			// 		r.TotalM.Lock()
-			// 		r.Total--
-			// 		r.TotalM.Unlock()
-			// 		logger.Infof("player %v was disconnected (game session %v)", pv.UserInfo.UID, pv.GameSessionID)
-			// 	}
-			// 	return true
-			// })
-			r = rv
-			return false
-		}
-		return true
+		return r == nil
 	})
+	return r, p
+}
+
+// matchPlayers groups players waiting in the queue and starts games for them.
+func (g *Game) matchPlayers() {
+	if g.Draining() {
+		return
+	}
+	for _, mode := range []string{ModeSolo, ModeTeam} {
+		for _, group := range g.Matchmaker.Match(time.Now(), mode, g.roomSize(mode)) {
+			r, err := g.createRoom(mode)
+			if err != nil {
+				logger.Error(err)
+				// players will be matched again on the next try
+				for _, t := range group {
+					g.Matchmaker.Push(t)
+				}
+				continue
+			}
+			for _, t := range group {
+				g.joinRoom(r, t.Player)
+			}
+			r.broadcast(&WSMessageToSend{
+				Status: "matched",
+			})
+			go r.Run()
+		}
+		if g.botWait == 0 {
+			continue
+		}
+		for _, t := range g.Matchmaker.TakeWaiting(time.Now(), mode, g.botWait) {
+			err := g.startBotGame(t.Player, mode, botLevelFor(t.Rating))
+			if err != nil {
+				logger.Error(err)
+				g.Matchmaker.Push(t)
+			}
+		}
+	}
+	if g.matchTimeout != 0 {
+		for _, mode := range []string{ModeSolo, ModeTeam} {
+			for _, t := range g.Matchmaker.TakeWaiting(time.Now(), mode, g.matchTimeout) {
+				logger.Infof("no opponent for player %v (game session %v) in %v", t.Player.UserInfo.UID, t.Player.GameSessionID, g.matchTimeout)
+				t.Player.sendAway("no_opponent")
+			}
+		}
+	}
+	metrics.SetWaitingPlayers(g.Matchmaker.Len())
+}
 
+// startBotGame starts the game of the mode for the player with bots of given level
+// in all the other slots of the room.
+func (g *Game) startBotGame(p *Player, mode, level string) error {
+	r, err := g.createRoom(mode)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if r != nil {
-		return r, nil
+	g.joinRoom(r, p)
+	for i := 1; i < g.roomSize(mode); i++ {
+		g.joinRoom(r, NewBot(level, p.Rating))
 	}
+	logger.Infof("player %v plays with %v bots in room %v", p.UserInfo.UID, level, r.ID)
+	r.broadcast(&WSMessageToSend{
+		Status: "matched",
+	})
+	go r.Run()
+
+	return nil
+}
+
+// joinRoom adds Player to Room and starts sending messages to him.
+func (g *Game) joinRoom(r *Room, p *Player) {
+	r.Players.Store(p.GameSessionID, p)
+	r.TotalM.Lock()
+	r.Total++
+	r.TotalM.Unlock()
+	p.setRoom(r)
+	if p.isBot() {
+		go p.Play()
+	} else {
+		p.startSend()
+	}
+	logger.Infof("player %v (game session %v) joined room %v", p.UserInfo.UID, p.GameSessionID, r.ID)
+}
+
+// createRoom creates new room of the game mode if the limit of rooms is not reached.
+func (g *Game) createRoom(mode string) (*Room, error) {
 	if g.Total >= MaxRooms {
 		return nil, ErrMaxRooms
 	}
 
-	r = NewRoom()
+	r := NewRoom(g.rules, mode)
 	g.TotalM.Lock()
 	g.Total++
 	metrics.AddRoomToCounter()
 	g.TotalM.Unlock()
 	g.Rooms.Store(r.ID, r)
-	logger.Infof("room %v created, total %v", r.ID, g.Total)
+	logger.Infof("room %v (%v mode) created, total %v", r.ID, mode, g.Total)
 
 	return r, nil
 }
 
-// saveResults saves players' results to database (to their profiles)
+// saveResults saves players' results to database (to their profiles and match history).
+// Results are written in one transaction, failed writes are retried from the outbox.
 func (g *Game) saveResults(r *Room) {
-	logger.Infof("saving results of room %v...", r.ID)
 	if r.engine.status == nil {
 		logger.Errorf("saveResults: nil status in room")
+		metrics.AddFailedResultSave()
 		return
 	}
-	var player1, player2 *Player
-	r.Players.Range(func(k, v interface{}) bool {
-		pv := v.(*Player)
-		if r.engine.Players[pv.GameSessionID] == 1 {
-			player1 = pv
-		} else {
-			player2 = pv
-		}
-		return true
-	})
-
-	switch r.engine.status.Reason {
-	case TimeOver:
-		player1Score := r.engine.state.Player1.Score
-		player2Score := r.engine.state.Player2.Score
-		player1Record := &models.Record{
-			UID:    player1.UserInfo.UID,
-			Record: player1Score,
-		}
-		player2Record := &models.Record{
-			UID:    player2.UserInfo.UID,
-			Record: player2Score,
-		}
-		switch {
-		case (player1Score == player2Score) || (player1Score < 0 && player2Score < 0):
-			player1Record.GameResult = models.Draw
-			player2Record.GameResult = models.Draw
-			err := database.UpdateStats(g.dm, player1Record)
-			if err != nil {
-				logger.Errorf("failed to save player1 %v result: %v", player1.GameSessionID, err)
-			}
-			err = database.UpdateStats(g.dm, player2Record)
-			if err != nil {
-				logger.Errorf("failed to save player2 %v result: %v", player2.GameSessionID, err)
-			}
-
-			if player1Score == player2Score {
-				coins := int(math.Round(DrawCoinsCoefficient * float64(player1Score)))
-				err = database.ChangeUserCoinAmount(g.dm, player1Record.UID, coins)
-				if err != nil {
-					logger.Errorf("failed to save player1 %v coins: %v", player1.GameSessionID, err)
-				}
-				err = database.ChangeUserCoinAmount(g.dm, player2Record.UID, coins)
-				if err != nil {
-					logger.Errorf("failed to save player2 %v coins: %v", player2.GameSessionID, err)
-				}
-			}
-		case player1Score > player2Score:
-			player1Record.GameResult = models.Win
-			player2Record.GameResult = models.Loss
-			err := database.UpdateStats(g.dm, player1Record)
-			if err != nil {
-				logger.Errorf("failed to save player1 %v result: %v", player1.GameSessionID, err)
-			}
-			err = database.UpdateStats(g.dm, player2Record)
-			if err != nil {
-				logger.Errorf("failed to save player2 %v result: %v", player2.GameSessionID, err)
-			}
-
-			coins := int(math.Round(WinnerCoinsCoefficient * float64(player1Score)))
-			err = database.ChangeUserCoinAmount(g.dm, player1Record.UID, coins)
-			if err != nil {
-				logger.Errorf("failed to save player1 %v coins: %v", player1.GameSessionID, err)
-			}
-			err = database.ChangeUserCoinAmount(g.dm, player2Record.UID, LoserCoinsAmount)
-			if err != nil {
-				logger.Errorf("failed to save player2 %v coins: %v", player2.GameSessionID, err)
-			}
-		case player1Score < player2Score:
-			player2Record.GameResult = models.Win
-			player1Record.GameResult = models.Loss
-			err := database.UpdateStats(g.dm, player2Record)
-			if err != nil {
-				logger.Errorf("failed to save player2 %v result: %v", player2.GameSessionID, err)
-			}
-			err = database.UpdateStats(g.dm, player1Record)
-			if err != nil {
-				logger.Errorf("failed to save player1 %v result: %v", player1.GameSessionID, err)
-			}
+	logger.Infof("saving results of room %v (seed %v)...", r.ID, r.engine.Seed)
 
-			coins := int(math.Round(WinnerCoinsCoefficient * float64(player2Score)))
-			err = database.ChangeUserCoinAmount(g.dm, player2Record.UID, coins)
-			if err != nil {
-				logger.Errorf("failed to save player2 %v coins: %v", player2.GameSessionID, err)
-			}
-			err = database.ChangeUserCoinAmount(g.dm, player1Record.UID, LoserCoinsAmount)
-			if err != nil {
-				logger.Errorf("failed to save player1 %v coins: %v", player1.GameSessionID, err)
-			}
-		}
-	case Disconnected:
-		left := r.engine.status.Info.(*Player)
-		loserScore := 0
-		var winner *Player
-		winnerScore := 0
-		if player1 != nil && player1.GameSessionID != left.GameSessionID {
-			winner = player1
-			winnerScore = r.engine.state.Player1.Score
-			loserScore = r.engine.state.Player2.Score
-		} else {
-			winner = player2
-			winnerScore = r.engine.state.Player2.Score
-			loserScore = r.engine.state.Player1.Score
+	match := &models.Match{
+		RoomID:       r.ID,
+		Mode:         r.mode,
+		Started:      r.startedAt,
+		Ended:        r.endedAt,
+		EndReason:    endReason(r.engine.status.Reason),
+		Seed:         r.engine.Seed,
+		Casual:       r.casual,
+		Participants: make([]*models.MatchParticipant, 0, len(r.results)),
+	}
+	for _, res := range r.results {
+		if res.UID == BotUID { // bots have no profiles
+			continue
 		}
-		err := database.UpdateStats(g.dm, &models.Record{
-			UID:        winner.UserInfo.UID,
-			Record:     winnerScore,
-			GameResult: models.Win,
-		})
-		if err != nil {
-			logger.Errorf("failed to save winner %v result: %v", winner.GameSessionID, err)
+		if res.Flagged {
+			logger.Infof("room %v: player %v is flagged as cheater", r.ID, res.UID)
 		}
-		coins := int(math.Round(WinnerCoinsCoefficient * float64(r.engine.state.Player1.Score)))
-		err = database.ChangeUserCoinAmount(g.dm, winner.UserInfo.UID, coins)
-		if err != nil {
-			logger.Errorf("failed to save winner %v coins: %v", winner.GameSessionID, err)
+		match.Participants = append(match.Participants, res)
+	}
+	sort.Slice(match.Participants, func(i, j int) bool {
+		if match.Participants[i].Place != match.Participants[j].Place {
+			return match.Participants[i].Place < match.Participants[j].Place
 		}
-		// player 2 is loser (left game)
-		err = database.UpdateStats(g.dm, &models.Record{
-			UID:        left.UserInfo.UID,
-			Record:     loserScore,
-			GameResult: models.Loss,
-		})
-		if err != nil {
-			logger.Errorf("failed to save left player2 %v result: %v", left.GameSessionID, err)
+		if match.Participants[i].Team != match.Participants[j].Team {
+			return match.Participants[i].Team < match.Participants[j].Team
 		}
-	default:
-		logger.Error("invalid data about left player and winner")
+		return match.Participants[i].PlayerNum < match.Participants[j].PlayerNum
+	})
+
+	err := g.outbox.Put(match)
+	if err != nil {
+		logger.Errorf("failed to put results of room %v to outbox: %v", r.ID, err)
+	}
+	if !g.writeResults(match) && err != nil {
+		logger.Errorf("results of room %v are lost", r.ID)
+		metrics.AddFailedResultSave()
 	}
 }
 
-// InitGodGameObject initializes new object of Game.
-func InitGodGameObject(dm *db.DatabaseManager) *Game {
+// GetMatchHistory returns the page of user's match history.
+func GetMatchHistory(uID uint, limit, offset int) (models.MatchHistory, error) {
+	return database.GetUserMatches(g.dm, uID, limit, offset)
+}
+
+// InitGodGameObject initializes new object of Game. Players waiting in the queue
+// longer than botWait play with bots, bots are disabled if botWait is 0.
+// Players waiting longer than matchTimeout are sent away, they wait forever if it is 0.
+func InitGodGameObject(dm *db.DatabaseManager, outbox *Outbox, rules *GameRules, botWait, matchTimeout time.Duration) *Game {
 	g = &Game{
-		Rooms:     &sync.Map{},
-		TotalM:    &sync.Mutex{},
-		Register:  make(chan *User, 1),
-		CloseRoom: make(chan *Room, 1),
-		dm:        dm,
+		Rooms:        &sync.Map{},
+		TotalM:       &sync.Mutex{},
+		Matchmaker:   NewMatchmaker(),
+		invites:      make(map[string]*Room),
+		invitesM:     &sync.Mutex{},
+		Register:     make(chan *User, 1),
+		Leave:        make(chan *Player, 16),
+		Cancel:       make(chan *Player, 16),
+		CloseRoom:    make(chan *Room, 1),
+		dm:           dm,
+		outbox:       outbox,
+		rules:        rules,
+		botWait:      botWait,
+		matchTimeout: matchTimeout,
 	}
 	return g
 }
@@ -311,3 +401,25 @@ func InitGodGameObject(dm *db.DatabaseManager) *Game {
 func AddPlayer(u *User) {
 	g.Register <- u
 }
+
+// RoomStarted checks if the game in the room with given ID has started and is not over.
+func RoomStarted(roomID string) bool {
+	v, ok := g.Rooms.Load(roomID)
+	return ok && v.(*Room).isStarted()
+}
+
+// AddSpectator attaches the connection to the room with given ID as spectator.
+// Rooms still waiting for players have nothing to show, ErrNoRoom is returned for them.
+func AddSpectator(conn *websocket.Conn, roomID string) error {
+	v, ok := g.Rooms.Load(roomID)
+	if !ok || !v.(*Room).isStarted() {
+		return ErrNoRoom
+	}
+	r := v.(*Room)
+	select {
+	case r.AddSpectator <- NewSpectator(conn):
+		return nil
+	case <-r.Ctx.Done():
+		return ErrNoRoom
+	}
+}
@@ -113,7 +113,7 @@ func (v *WSMessageToSend) UnmarshalJSON(data []byte) error {
 func (v *WSMessageToSend) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson85f0d656DecodeGameGame(l, v)
 }
-func easyjson85f0d656DecodeGameGame1(in *jlexer.Lexer, out *State) {
+func easyjson85f0d656DecodeGameGame1(in *jlexer.Lexer, out *TeamData) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -132,53 +132,270 @@ func easyjson85f0d656DecodeGameGame1(in *jlexer.Lexer, out *State) {
 			continue
 		}
 		switch key {
-		case "player1":
+		case "score":
+			out.Score = int(in.Int())
+		case "targetList":
 			if in.IsNull() {
 				in.Skip()
-				out.Player1 = nil
+				out.TargetList = nil
 			} else {
-				if out.Player1 == nil {
-					out.Player1 = new(PlayerData)
+				in.Delim('[')
+				if out.TargetList == nil {
+					if !in.IsDelim(']') {
+						out.TargetList = make([]int, 0, 8)
+					} else {
+						out.TargetList = []int{}
+					}
+				} else {
+					out.TargetList = (out.TargetList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int
+					v1 = int(in.Int())
+					out.TargetList = append(out.TargetList, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame1(out *jwriter.Writer, in TeamData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"targetList\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.TargetList == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.TargetList {
+				if v2 > 0 {
+					out.RawByte(',')
 				}
-				(*out.Player1).UnmarshalEasyJSON(in)
+				out.Int(int(v3))
 			}
-		case "player2":
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TeamData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TeamData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TeamData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TeamData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame1(l, v)
+}
+func easyjson85f0d656DecodeGameGame2(in *jlexer.Lexer, out *StateDelta) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = int(in.Int())
+		case "base":
+			out.Base = int(in.Int())
+		case "tick":
+			out.Tick = int(in.Int())
+		case "players":
 			if in.IsNull() {
 				in.Skip()
-				out.Player2 = nil
+				out.Players = nil
 			} else {
-				if out.Player2 == nil {
-					out.Player2 = new(PlayerData)
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]*ChangedPlayer, 0, 8)
+					} else {
+						out.Players = []*ChangedPlayer{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 *ChangedPlayer
+					if in.IsNull() {
+						in.Skip()
+						v4 = nil
+					} else {
+						if v4 == nil {
+							v4 = new(ChangedPlayer)
+						}
+						(*v4).UnmarshalEasyJSON(in)
+					}
+					out.Players = append(out.Players, v4)
+					in.WantComma()
 				}
-				(*out.Player2).UnmarshalEasyJSON(in)
+				in.Delim(']')
 			}
-		case "products":
+		case "teams":
 			if in.IsNull() {
 				in.Skip()
-				out.Products = nil
+				out.Teams = nil
 			} else {
 				in.Delim('[')
-				if out.Products == nil {
+				if out.Teams == nil {
 					if !in.IsDelim(']') {
-						out.Products = make([]*ProductData, 0, 8)
+						out.Teams = make([]*ChangedTeam, 0, 8)
 					} else {
-						out.Products = []*ProductData{}
+						out.Teams = []*ChangedTeam{}
 					}
 				} else {
-					out.Products = (out.Products)[:0]
+					out.Teams = (out.Teams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 *ChangedTeam
+					if in.IsNull() {
+						in.Skip()
+						v5 = nil
+					} else {
+						if v5 == nil {
+							v5 = new(ChangedTeam)
+						}
+						(*v5).UnmarshalEasyJSON(in)
+					}
+					out.Teams = append(out.Teams, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "added":
+			if in.IsNull() {
+				in.Skip()
+				out.Added = nil
+			} else {
+				in.Delim('[')
+				if out.Added == nil {
+					if !in.IsDelim(']') {
+						out.Added = make([]*ProductData, 0, 8)
+					} else {
+						out.Added = []*ProductData{}
+					}
+				} else {
+					out.Added = (out.Added)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 *ProductData
+					if in.IsNull() {
+						in.Skip()
+						v6 = nil
+					} else {
+						if v6 == nil {
+							v6 = new(ProductData)
+						}
+						(*v6).UnmarshalEasyJSON(in)
+					}
+					out.Added = append(out.Added, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "moved":
+			if in.IsNull() {
+				in.Skip()
+				out.Moved = nil
+			} else {
+				in.Delim('[')
+				if out.Moved == nil {
+					if !in.IsDelim(']') {
+						out.Moved = make([]*MovedProduct, 0, 8)
+					} else {
+						out.Moved = []*MovedProduct{}
+					}
+				} else {
+					out.Moved = (out.Moved)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 *ProductData
+					var v7 *MovedProduct
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
+						v7 = nil
 					} else {
-						if v1 == nil {
-							v1 = new(ProductData)
+						if v7 == nil {
+							v7 = new(MovedProduct)
 						}
-						(*v1).UnmarshalEasyJSON(in)
+						(*v7).UnmarshalEasyJSON(in)
+					}
+					out.Moved = append(out.Moved, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "removed":
+			if in.IsNull() {
+				in.Skip()
+				out.Removed = nil
+			} else {
+				in.Delim('[')
+				if out.Removed == nil {
+					if !in.IsDelim(']') {
+						out.Removed = make([]int, 0, 8)
+					} else {
+						out.Removed = []int{}
 					}
-					out.Products = append(out.Products, v1)
+				} else {
+					out.Removed = (out.Removed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 int
+					v8 = int(in.Int())
+					out.Removed = append(out.Removed, v8)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -199,9 +416,9 @@ func easyjson85f0d656DecodeGameGame1(in *jlexer.Lexer, out *State) {
 					out.Collected = (out.Collected)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v2 PointsData
-					(v2).UnmarshalEasyJSON(in)
-					out.Collected = append(out.Collected, v2)
+					var v9 PointsData
+					(v9).UnmarshalEasyJSON(in)
+					out.Collected = append(out.Collected, v9)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -216,107 +433,2334 @@ func easyjson85f0d656DecodeGameGame1(in *jlexer.Lexer, out *State) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame1(out *jwriter.Writer, in State) {
+func easyjson85f0d656EncodeGameGame2(out *jwriter.Writer, in StateDelta) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"base\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Base))
+	}
+	{
+		const prefix string = ",\"tick\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Tick))
+	}
+	if len(in.Players) != 0 {
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.Players {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				if v11 == nil {
+					out.RawString("null")
+				} else {
+					(*v11).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Teams) != 0 {
+		const prefix string = ",\"teams\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Teams {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				if v13 == nil {
+					out.RawString("null")
+				} else {
+					(*v13).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Added) != 0 {
+		const prefix string = ",\"added\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Added {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				if v15 == nil {
+					out.RawString("null")
+				} else {
+					(*v15).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Moved) != 0 {
+		const prefix string = ",\"moved\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v16, v17 := range in.Moved {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				if v17 == nil {
+					out.RawString("null")
+				} else {
+					(*v17).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Removed) != 0 {
+		const prefix string = ",\"removed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.Removed {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v19))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Collected) != 0 {
+		const prefix string = ",\"collected\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Collected {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateDelta) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateDelta) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateDelta) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateDelta) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame2(l, v)
+}
+func easyjson85f0d656DecodeGameGame3(in *jlexer.Lexer, out *State) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = int(in.Int())
+		case "tick":
+			out.Tick = int(in.Int())
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]*PlayerData, 0, 8)
+					} else {
+						out.Players = []*PlayerData{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 *PlayerData
+					if in.IsNull() {
+						in.Skip()
+						v22 = nil
+					} else {
+						if v22 == nil {
+							v22 = new(PlayerData)
+						}
+						(*v22).UnmarshalEasyJSON(in)
+					}
+					out.Players = append(out.Players, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "teams":
+			if in.IsNull() {
+				in.Skip()
+				out.Teams = nil
+			} else {
+				in.Delim('[')
+				if out.Teams == nil {
+					if !in.IsDelim(']') {
+						out.Teams = make([]*TeamData, 0, 8)
+					} else {
+						out.Teams = []*TeamData{}
+					}
+				} else {
+					out.Teams = (out.Teams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v23 *TeamData
+					if in.IsNull() {
+						in.Skip()
+						v23 = nil
+					} else {
+						if v23 == nil {
+							v23 = new(TeamData)
+						}
+						(*v23).UnmarshalEasyJSON(in)
+					}
+					out.Teams = append(out.Teams, v23)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "products":
+			if in.IsNull() {
+				in.Skip()
+				out.Products = nil
+			} else {
+				in.Delim('[')
+				if out.Products == nil {
+					if !in.IsDelim(']') {
+						out.Products = make([]*ProductData, 0, 8)
+					} else {
+						out.Products = []*ProductData{}
+					}
+				} else {
+					out.Products = (out.Products)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v24 *ProductData
+					if in.IsNull() {
+						in.Skip()
+						v24 = nil
+					} else {
+						if v24 == nil {
+							v24 = new(ProductData)
+						}
+						(*v24).UnmarshalEasyJSON(in)
+					}
+					out.Products = append(out.Products, v24)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "collected":
+			if in.IsNull() {
+				in.Skip()
+				out.Collected = nil
+			} else {
+				in.Delim('[')
+				if out.Collected == nil {
+					if !in.IsDelim(']') {
+						out.Collected = make([]PointsData, 0, 2)
+					} else {
+						out.Collected = []PointsData{}
+					}
+				} else {
+					out.Collected = (out.Collected)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 PointsData
+					(v25).UnmarshalEasyJSON(in)
+					out.Collected = append(out.Collected, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame3(out *jwriter.Writer, in State) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"tick\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Tick))
+	}
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v26, v27 := range in.Players {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				if v27 == nil {
+					out.RawString("null")
+				} else {
+					(*v27).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Teams) != 0 {
+		const prefix string = ",\"teams\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v28, v29 := range in.Teams {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				if v29 == nil {
+					out.RawString("null")
+				} else {
+					(*v29).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Products) != 0 {
+		const prefix string = ",\"products\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v30, v31 := range in.Products {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				if v31 == nil {
+					out.RawString("null")
+				} else {
+					(*v31).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Collected) != 0 {
+		const prefix string = ",\"collected\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v32, v33 := range in.Collected {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				(v33).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v State) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v State) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *State) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *State) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame3(l, v)
+}
+func easyjson85f0d656DecodeGameGame4(in *jlexer.Lexer, out *StartInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]uint, 0, 8)
+					} else {
+						out.Players = []uint{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 uint
+					v34 = uint(in.Uint())
+					out.Players = append(out.Players, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "playerNum":
+			out.PlayerNum = uint(in.Uint())
+		case "mode":
+			out.Mode = string(in.String())
+		case "stateConst":
+			if in.IsNull() {
+				in.Skip()
+				out.Constants = nil
+			} else {
+				if out.Constants == nil {
+					out.Constants = new(GameRules)
+				}
+				(*out.Constants).UnmarshalEasyJSON(in)
+			}
+		case "elapsed":
+			out.Elapsed = time.Duration(in.Int64())
+		case "lastInput":
+			out.LastInput = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame4(out *jwriter.Writer, in StartInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.Players {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint(uint(v36))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"playerNum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.PlayerNum))
+	}
+	{
+		const prefix string = ",\"mode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"stateConst\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Constants == nil {
+			out.RawString("null")
+		} else {
+			(*in.Constants).MarshalEasyJSON(out)
+		}
+	}
+	if in.Elapsed != 0 {
+		const prefix string = ",\"elapsed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Elapsed))
+	}
+	if in.LastInput != 0 {
+		const prefix string = ",\"lastInput\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.LastInput))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StartInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StartInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StartInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StartInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame4(l, v)
+}
+func easyjson85f0d656DecodeGameGame5(in *jlexer.Lexer, out *SpectateInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]uint, 0, 8)
+					} else {
+						out.Players = []uint{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 uint
+					v37 = uint(in.Uint())
+					out.Players = append(out.Players, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mode":
+			out.Mode = string(in.String())
+		case "stateConst":
+			if in.IsNull() {
+				in.Skip()
+				out.Constants = nil
+			} else {
+				if out.Constants == nil {
+					out.Constants = new(GameRules)
+				}
+				(*out.Constants).UnmarshalEasyJSON(in)
+			}
+		case "elapsed":
+			out.Elapsed = time.Duration(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame5(out *jwriter.Writer, in SpectateInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v38, v39 := range in.Players {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint(uint(v39))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"mode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"stateConst\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Constants == nil {
+			out.RawString("null")
+		} else {
+			(*in.Constants).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"elapsed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Elapsed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SpectateInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SpectateInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SpectateInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SpectateInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame5(l, v)
+}
+func easyjson85f0d656DecodeGameGame6(in *jlexer.Lexer, out *RoomsInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RoomsInfo, 0, 8)
+			} else {
+				*out = RoomsInfo{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v40 *RoomInfo
+			if in.IsNull() {
+				in.Skip()
+				v40 = nil
+			} else {
+				if v40 == nil {
+					v40 = new(RoomInfo)
+				}
+				(*v40).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v40)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame6(out *jwriter.Writer, in RoomsInfo) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v41, v42 := range in {
+			if v41 > 0 {
+				out.RawByte(',')
+			}
+			if v42 == nil {
+				out.RawString("null")
+			} else {
+				(*v42).MarshalEasyJSON(out)
+			}
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomsInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomsInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomsInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomsInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame6(l, v)
+}
+func easyjson85f0d656DecodeGameGame7(in *jlexer.Lexer, out *RoomPlayerInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "uid":
+			out.UID = uint(in.Uint())
+		case "playerNum":
+			out.PlayerNum = int(in.Int())
+		case "team":
+			out.Team = int(in.Int())
+		case "score":
+			out.Score = int(in.Int())
+		case "disconnected":
+			out.Disconnected = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame7(out *jwriter.Writer, in RoomPlayerInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uid\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.UID))
+	}
+	if in.PlayerNum != 0 {
+		const prefix string = ",\"playerNum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.PlayerNum))
+	}
+	if in.Team != 0 {
+		const prefix string = ",\"team\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Team))
+	}
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	if in.Disconnected {
+		const prefix string = ",\"disconnected\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Disconnected))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomPlayerInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomPlayerInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomPlayerInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomPlayerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame7(l, v)
+}
+func easyjson85f0d656DecodeGameGame8(in *jlexer.Lexer, out *RoomInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "mode":
+			out.Mode = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "private":
+			out.Private = bool(in.Bool())
+		case "elapsed":
+			out.Elapsed = time.Duration(in.Int64())
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]*RoomPlayerInfo, 0, 8)
+					} else {
+						out.Players = []*RoomPlayerInfo{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v43 *RoomPlayerInfo
+					if in.IsNull() {
+						in.Skip()
+						v43 = nil
+					} else {
+						if v43 == nil {
+							v43 = new(RoomPlayerInfo)
+						}
+						(*v43).UnmarshalEasyJSON(in)
+					}
+					out.Players = append(out.Players, v43)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame8(out *jwriter.Writer, in RoomInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"mode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.Private {
+		const prefix string = ",\"private\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Private))
+	}
+	{
+		const prefix string = ",\"elapsed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Elapsed))
+	}
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v44, v45 := range in.Players {
+				if v44 > 0 {
+					out.RawByte(',')
+				}
+				if v45 == nil {
+					out.RawString("null")
+				} else {
+					(*v45).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame8(l, v)
+}
+func easyjson85f0d656DecodeGameGame9(in *jlexer.Lexer, out *RoomDetails) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "info":
+			if in.IsNull() {
+				in.Skip()
+				out.Info = nil
+			} else {
+				if out.Info == nil {
+					out.Info = new(RoomInfo)
+				}
+				(*out.Info).UnmarshalEasyJSON(in)
+			}
+		case "state":
+			if in.IsNull() {
+				in.Skip()
+				out.State = nil
+			} else {
+				if out.State == nil {
+					out.State = new(State)
+				}
+				(*out.State).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame9(out *jwriter.Writer, in RoomDetails) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"info\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Info == nil {
+			out.RawString("null")
+		} else {
+			(*in.Info).MarshalEasyJSON(out)
+		}
+	}
+	if in.State != nil {
+		const prefix string = ",\"state\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.State).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomDetails) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomDetails) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomDetails) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomDetails) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame9(l, v)
+}
+func easyjson85f0d656DecodeGameGame10(in *jlexer.Lexer, out *ReplayInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "t":
+			out.Tick = int(in.Int())
+		case "p":
+			out.Player = int(in.Int())
+		case "s":
+			out.Seq = int(in.Int())
+		case "a":
+			out.Actions = Actions(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame10(out *jwriter.Writer, in ReplayInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"t\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Tick))
+	}
+	{
+		const prefix string = ",\"p\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Player))
+	}
+	if in.Seq != 0 {
+		const prefix string = ",\"s\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Seq))
+	}
+	{
+		const prefix string = ",\"a\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Actions))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReplayInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReplayInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReplayInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReplayInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame10(l, v)
+}
+func easyjson85f0d656DecodeGameGame11(in *jlexer.Lexer, out *Replay) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomId":
+			out.RoomID = string(in.String())
+		case "seed":
+			out.Seed = int64(in.Int64())
+		case "players":
+			if in.IsNull() {
+				in.Skip()
+				out.Players = nil
+			} else {
+				in.Delim('[')
+				if out.Players == nil {
+					if !in.IsDelim(']') {
+						out.Players = make([]uint, 0, 8)
+					} else {
+						out.Players = []uint{}
+					}
+				} else {
+					out.Players = (out.Players)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v46 uint
+					v46 = uint(in.Uint())
+					out.Players = append(out.Players, v46)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "v":
+			out.Version = int(in.Int())
+		case "mode":
+			out.Mode = string(in.String())
+		case "stateConst":
+			if in.IsNull() {
+				in.Skip()
+				out.Constants = nil
+			} else {
+				if out.Constants == nil {
+					out.Constants = new(GameRules)
+				}
+				(*out.Constants).UnmarshalEasyJSON(in)
+			}
+		case "inputs":
+			if in.IsNull() {
+				in.Skip()
+				out.Inputs = nil
+			} else {
+				in.Delim('[')
+				if out.Inputs == nil {
+					if !in.IsDelim(']') {
+						out.Inputs = make([]*ReplayInput, 0, 8)
+					} else {
+						out.Inputs = []*ReplayInput{}
+					}
+				} else {
+					out.Inputs = (out.Inputs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v47 *ReplayInput
+					if in.IsNull() {
+						in.Skip()
+						v47 = nil
+					} else {
+						if v47 == nil {
+							v47 = new(ReplayInput)
+						}
+						(*v47).UnmarshalEasyJSON(in)
+					}
+					out.Inputs = append(out.Inputs, v47)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "endTick":
+			out.EndTick = int(in.Int())
+		case "reason":
+			out.Reason = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame11(out *jwriter.Writer, in Replay) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RoomID))
+	}
+	{
+		const prefix string = ",\"seed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Seed))
+	}
+	{
+		const prefix string = ",\"players\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Players == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v48, v49 := range in.Players {
+				if v48 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint(uint(v49))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"v\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"mode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"stateConst\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Constants == nil {
+			out.RawString("null")
+		} else {
+			(*in.Constants).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"inputs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Inputs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v50, v51 := range in.Inputs {
+				if v50 > 0 {
+					out.RawByte(',')
+				}
+				if v51 == nil {
+					out.RawString("null")
+				} else {
+					(*v51).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"endTick\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.EndTick))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Replay) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Replay) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Replay) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Replay) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame11(l, v)
+}
+func easyjson85f0d656DecodeGameGame12(in *jlexer.Lexer, out *ProtocolError) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame12(out *jwriter.Writer, in ProtocolError) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProtocolError) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProtocolError) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProtocolError) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProtocolError) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame12(l, v)
+}
+func easyjson85f0d656DecodeGameGame13(in *jlexer.Lexer, out *ProductData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "X":
+			out.X = float64(in.Float64())
+		case "Y":
+			out.Y = float64(in.Float64())
+		case "type":
+			out.Type = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame13(out *jwriter.Writer, in ProductData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"X\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.X))
+	}
+	{
+		const prefix string = ",\"Y\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.Y))
+	}
+	{
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Type))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProductData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProductData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProductData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProductData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame13(l, v)
+}
+func easyjson85f0d656DecodeGameGame14(in *jlexer.Lexer, out *PointsData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "X":
+			out.X = float64(in.Float64())
+		case "Y":
+			out.Y = float64(in.Float64())
+		case "playerNum":
+			out.Who = int(in.Int())
+		case "points":
+			out.Points = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame14(out *jwriter.Writer, in PointsData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"X\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.X))
+	}
+	{
+		const prefix string = ",\"Y\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.Y))
+	}
+	{
+		const prefix string = ",\"playerNum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Who))
+	}
+	{
+		const prefix string = ",\"points\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Points))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PointsData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PointsData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PointsData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PointsData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame14(l, v)
+}
+func easyjson85f0d656DecodeGameGame15(in *jlexer.Lexer, out *PlayerData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "score":
+			out.Score = int(in.Int())
+		case "X":
+			out.X = float64(in.Float64())
+		case "Y":
+			out.Y = float64(in.Float64())
+		case "targetList":
+			if in.IsNull() {
+				in.Skip()
+				out.TargetList = nil
+			} else {
+				in.Delim('[')
+				if out.TargetList == nil {
+					if !in.IsDelim(']') {
+						out.TargetList = make([]int, 0, 8)
+					} else {
+						out.TargetList = []int{}
+					}
+				} else {
+					out.TargetList = (out.TargetList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v52 int
+					v52 = int(in.Int())
+					out.TargetList = append(out.TargetList, v52)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "team":
+			out.Team = int(in.Int())
+		case "lastInput":
+			out.LastInput = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame15(out *jwriter.Writer, in PlayerData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"X\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.X))
+	}
+	{
+		const prefix string = ",\"Y\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.Y))
+	}
+	{
+		const prefix string = ",\"targetList\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.TargetList == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v53, v54 := range in.TargetList {
+				if v53 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v54))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Team != 0 {
+		const prefix string = ",\"team\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Team))
+	}
+	if in.LastInput != 0 {
+		const prefix string = ",\"lastInput\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.LastInput))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PlayerData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PlayerData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PlayerData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PlayerData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame15(l, v)
+}
+func easyjson85f0d656DecodeGameGame16(in *jlexer.Lexer, out *OpponentInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "playerNum":
+			out.PlayerNum = uint(in.Uint())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame16(out *jwriter.Writer, in OpponentInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"player1\":"
+		const prefix string = ",\"playerNum\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Player1 == nil {
-			out.RawString("null")
-		} else {
-			(*in.Player1).MarshalEasyJSON(out)
+		out.Uint(uint(in.PlayerNum))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OpponentInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OpponentInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OpponentInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OpponentInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame16(l, v)
+}
+func easyjson85f0d656DecodeGameGame17(in *jlexer.Lexer, out *MovedProduct) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "Y":
+			out.Y = float64(in.Float64())
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame17(out *jwriter.Writer, in MovedProduct) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"player2\":"
+		const prefix string = ",\"id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Player2 == nil {
-			out.RawString("null")
-		} else {
-			(*in.Player2).MarshalEasyJSON(out)
-		}
+		out.Int(int(in.ID))
 	}
-	if len(in.Products) != 0 {
-		const prefix string = ",\"products\":"
+	{
+		const prefix string = ",\"Y\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v3, v4 := range in.Products {
-				if v3 > 0 {
-					out.RawByte(',')
-				}
-				if v4 == nil {
-					out.RawString("null")
-				} else {
-					(*v4).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
+		out.Float64(float64(in.Y))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MovedProduct) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85f0d656EncodeGameGame17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MovedProduct) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MovedProduct) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85f0d656DecodeGameGame17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MovedProduct) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame17(l, v)
+}
+func easyjson85f0d656DecodeGameGame18(in *jlexer.Lexer, out *InviteInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
 		}
+		in.Skip()
+		return
 	}
-	if len(in.Collected) != 0 {
-		const prefix string = ",\"collected\":"
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "mode":
+			out.Mode = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85f0d656EncodeGameGame18(out *jwriter.Writer, in InviteInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v5, v6 := range in.Collected {
-				if v5 > 0 {
-					out.RawByte(',')
-				}
-				(v6).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"mode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
+		out.String(string(in.Mode))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v State) MarshalJSON() ([]byte, error) {
+func (v InviteInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame1(&w, v)
+	easyjson85f0d656EncodeGameGame18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v State) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame1(w, v)
+func (v InviteInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *State) UnmarshalJSON(data []byte) error {
+func (v *InviteInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame1(&r, v)
+	easyjson85f0d656DecodeGameGame18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *State) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame1(l, v)
+func (v *InviteInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame18(l, v)
 }
-func easyjson85f0d656DecodeGameGame2(in *jlexer.Lexer, out *StartInfo) {
+func easyjson85f0d656DecodeGameGame19(in *jlexer.Lexer, out *Input) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -335,20 +2779,12 @@ func easyjson85f0d656DecodeGameGame2(in *jlexer.Lexer, out *StartInfo) {
 			continue
 		}
 		switch key {
-		case "opponentId":
-			out.OpponentID = uint(in.Uint())
-		case "playerNum":
-			out.PlayerNum = uint(in.Uint())
-		case "stateConst":
-			if in.IsNull() {
-				in.Skip()
-				out.Constants = nil
-			} else {
-				if out.Constants == nil {
-					out.Constants = new(Const)
-				}
-				(*out.Constants).UnmarshalEasyJSON(in)
-			}
+		case "left":
+			out.Left = bool(in.Bool())
+		case "right":
+			out.Right = bool(in.Bool())
+		case "jump":
+			out.Jump = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -359,71 +2795,67 @@ func easyjson85f0d656DecodeGameGame2(in *jlexer.Lexer, out *StartInfo) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame2(out *jwriter.Writer, in StartInfo) {
+func easyjson85f0d656EncodeGameGame19(out *jwriter.Writer, in Input) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"opponentId\":"
+	if in.Left {
+		const prefix string = ",\"left\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Uint(uint(in.OpponentID))
+		out.Bool(bool(in.Left))
 	}
-	{
-		const prefix string = ",\"playerNum\":"
+	if in.Right {
+		const prefix string = ",\"right\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Uint(uint(in.PlayerNum))
+		out.Bool(bool(in.Right))
 	}
-	{
-		const prefix string = ",\"stateConst\":"
+	if in.Jump {
+		const prefix string = ",\"jump\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Constants == nil {
-			out.RawString("null")
-		} else {
-			(*in.Constants).MarshalEasyJSON(out)
-		}
+		out.Bool(bool(in.Jump))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v StartInfo) MarshalJSON() ([]byte, error) {
+func (v Input) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame2(&w, v)
+	easyjson85f0d656EncodeGameGame19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v StartInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame2(w, v)
+func (v Input) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *StartInfo) UnmarshalJSON(data []byte) error {
+func (v *Input) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame2(&r, v)
+	easyjson85f0d656DecodeGameGame19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *StartInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame2(l, v)
+func (v *Input) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame19(l, v)
 }
-func easyjson85f0d656DecodeGameGame3(in *jlexer.Lexer, out *ProductData) {
+func easyjson85f0d656DecodeGameGame20(in *jlexer.Lexer, out *GotMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -442,12 +2874,28 @@ func easyjson85f0d656DecodeGameGame3(in *jlexer.Lexer, out *ProductData) {
 			continue
 		}
 		switch key {
-		case "X":
-			out.X = float64(in.Float64())
-		case "Y":
-			out.Y = float64(in.Float64())
-		case "type":
-			out.Type = int(in.Int())
+		case "v":
+			out.Version = int(in.Int())
+		case "actions":
+			out.Actions = int(in.Int())
+		case "input":
+			if in.IsNull() {
+				in.Skip()
+				out.Input = nil
+			} else {
+				if out.Input == nil {
+					out.Input = new(Input)
+				}
+				(*out.Input).UnmarshalEasyJSON(in)
+			}
+		case "seq":
+			out.Seq = int(in.Int())
+		case "ack":
+			out.Ack = int(in.Int())
+		case "resync":
+			out.Resync = bool(in.Bool())
+		case "action":
+			out.Action = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -458,67 +2906,107 @@ func easyjson85f0d656DecodeGameGame3(in *jlexer.Lexer, out *ProductData) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame3(out *jwriter.Writer, in ProductData) {
+func easyjson85f0d656EncodeGameGame20(out *jwriter.Writer, in GotMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"X\":"
+	if in.Version != 0 {
+		const prefix string = ",\"v\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Version))
+	}
+	if in.Actions != 0 {
+		const prefix string = ",\"actions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Actions))
+	}
+	if in.Input != nil {
+		const prefix string = ",\"input\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Input).MarshalEasyJSON(out)
+	}
+	if in.Seq != 0 {
+		const prefix string = ",\"seq\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Seq))
+	}
+	if in.Ack != 0 {
+		const prefix string = ",\"ack\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.X))
+		out.Int(int(in.Ack))
 	}
-	{
-		const prefix string = ",\"Y\":"
+	if in.Resync {
+		const prefix string = ",\"resync\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.Y))
+		out.Bool(bool(in.Resync))
 	}
-	{
-		const prefix string = ",\"type\":"
+	if in.Action != "" {
+		const prefix string = ",\"action\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Type))
+		out.String(string(in.Action))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ProductData) MarshalJSON() ([]byte, error) {
+func (v GotMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame3(&w, v)
+	easyjson85f0d656EncodeGameGame20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ProductData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame3(w, v)
+func (v GotMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ProductData) UnmarshalJSON(data []byte) error {
+func (v *GotMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame3(&r, v)
+	easyjson85f0d656DecodeGameGame20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ProductData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame3(l, v)
+func (v *GotMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame20(l, v)
 }
-func easyjson85f0d656DecodeGameGame4(in *jlexer.Lexer, out *PointsData) {
+func easyjson85f0d656DecodeGameGame21(in *jlexer.Lexer, out *GameRules) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -537,14 +3025,38 @@ func easyjson85f0d656DecodeGameGame4(in *jlexer.Lexer, out *PointsData) {
 			continue
 		}
 		switch key {
-		case "X":
-			out.X = float64(in.Float64())
-		case "Y":
-			out.Y = float64(in.Float64())
-		case "playerNum":
-			out.Who = int(in.Int())
-		case "points":
-			out.Points = int(in.Int())
+		case "playersCount":
+			out.PlayersCount = int(in.Int())
+		case "gameTime":
+			out.GameTime = time.Duration(in.Int64())
+		case "msPerFrame":
+			out.MsPerFrame = time.Duration(in.Int64())
+		case "snapshotEvery":
+			out.SnapshotEvery = time.Duration(in.Int64())
+		case "targetCount":
+			out.TargetCount = int(in.Int())
+		case "targetVariaty":
+			out.TargetVariaty = int(in.Int())
+		case "targetRandomsEvery":
+			out.TargetRandomsEvery = time.Duration(in.Int64())
+		case "playerSpeed":
+			out.PlayerSpeed = float64(in.Float64())
+		case "playerJumpSpeed":
+			out.PlayerJumpSpeed = float64(in.Float64())
+		case "playerGravity":
+			out.PlayerGravity = float64(in.Float64())
+		case "playerSuccessPoints":
+			out.PlayerSuccessPoints = int(in.Int())
+		case "playerFailurePoints":
+			out.PlayerFailurePoints = int(in.Int())
+		case "productSpeed":
+			out.ProductSpeed = float64(in.Float64())
+		case "winnerCoinsCoefficient":
+			out.WinnerCoinsCoefficient = float64(in.Float64())
+		case "loserCoinsAmount":
+			out.LoserCoinsAmount = int(in.Int())
+		case "drawCoinsCoefficient":
+			out.DrawCoinsCoefficient = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -555,77 +3067,197 @@ func easyjson85f0d656DecodeGameGame4(in *jlexer.Lexer, out *PointsData) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame4(out *jwriter.Writer, in PointsData) {
+func easyjson85f0d656EncodeGameGame21(out *jwriter.Writer, in GameRules) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"X\":"
+		const prefix string = ",\"playersCount\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.X))
+		out.Int(int(in.PlayersCount))
 	}
 	{
-		const prefix string = ",\"Y\":"
+		const prefix string = ",\"gameTime\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.Y))
+		out.Int64(int64(in.GameTime))
 	}
 	{
-		const prefix string = ",\"playerNum\":"
+		const prefix string = ",\"msPerFrame\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Who))
+		out.Int64(int64(in.MsPerFrame))
 	}
 	{
-		const prefix string = ",\"points\":"
+		const prefix string = ",\"snapshotEvery\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Points))
+		out.Int64(int64(in.SnapshotEvery))
+	}
+	{
+		const prefix string = ",\"targetCount\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.TargetCount))
+	}
+	{
+		const prefix string = ",\"targetVariaty\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.TargetVariaty))
+	}
+	{
+		const prefix string = ",\"targetRandomsEvery\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.TargetRandomsEvery))
+	}
+	{
+		const prefix string = ",\"playerSpeed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.PlayerSpeed))
+	}
+	{
+		const prefix string = ",\"playerJumpSpeed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.PlayerJumpSpeed))
+	}
+	{
+		const prefix string = ",\"playerGravity\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.PlayerGravity))
+	}
+	{
+		const prefix string = ",\"playerSuccessPoints\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.PlayerSuccessPoints))
+	}
+	{
+		const prefix string = ",\"playerFailurePoints\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.PlayerFailurePoints))
+	}
+	{
+		const prefix string = ",\"productSpeed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.ProductSpeed))
+	}
+	{
+		const prefix string = ",\"winnerCoinsCoefficient\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.WinnerCoinsCoefficient))
+	}
+	{
+		const prefix string = ",\"loserCoinsAmount\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.LoserCoinsAmount))
+	}
+	{
+		const prefix string = ",\"drawCoinsCoefficient\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.DrawCoinsCoefficient))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PointsData) MarshalJSON() ([]byte, error) {
+func (v GameRules) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame4(&w, v)
+	easyjson85f0d656EncodeGameGame21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PointsData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame4(w, v)
+func (v GameRules) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PointsData) UnmarshalJSON(data []byte) error {
+func (v *GameRules) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame4(&r, v)
+	easyjson85f0d656DecodeGameGame21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PointsData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame4(l, v)
+func (v *GameRules) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame21(l, v)
 }
-func easyjson85f0d656DecodeGameGame5(in *jlexer.Lexer, out *PlayerData) {
+func easyjson85f0d656DecodeGameGame22(in *jlexer.Lexer, out *GameOverInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -644,35 +3276,12 @@ func easyjson85f0d656DecodeGameGame5(in *jlexer.Lexer, out *PlayerData) {
 			continue
 		}
 		switch key {
-		case "score":
-			out.Score = int(in.Int())
-		case "X":
-			out.X = float64(in.Float64())
-		case "Y":
-			out.Y = float64(in.Float64())
-		case "targetList":
-			if in.IsNull() {
-				in.Skip()
-				out.TargetList = nil
-			} else {
-				in.Delim('[')
-				if out.TargetList == nil {
-					if !in.IsDelim(']') {
-						out.TargetList = make([]int, 0, 8)
-					} else {
-						out.TargetList = []int{}
-					}
-				} else {
-					out.TargetList = (out.TargetList)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v7 int
-					v7 = int(in.Int())
-					out.TargetList = append(out.TargetList, v7)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "ratingDelta":
+			out.RatingDelta = int(in.Int())
+		case "rating":
+			out.Rating = int(in.Int())
+		case "seed":
+			out.Seed = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -683,88 +3292,67 @@ func easyjson85f0d656DecodeGameGame5(in *jlexer.Lexer, out *PlayerData) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame5(out *jwriter.Writer, in PlayerData) {
+func easyjson85f0d656EncodeGameGame22(out *jwriter.Writer, in GameOverInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"score\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Int(int(in.Score))
-	}
-	{
-		const prefix string = ",\"X\":"
+		const prefix string = ",\"ratingDelta\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.X))
+		out.Int(int(in.RatingDelta))
 	}
 	{
-		const prefix string = ",\"Y\":"
+		const prefix string = ",\"rating\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float64(float64(in.Y))
+		out.Int(int(in.Rating))
 	}
 	{
-		const prefix string = ",\"targetList\":"
+		const prefix string = ",\"seed\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.TargetList == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v8, v9 := range in.TargetList {
-				if v8 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v9))
-			}
-			out.RawByte(']')
-		}
+		out.Int64(int64(in.Seed))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PlayerData) MarshalJSON() ([]byte, error) {
+func (v GameOverInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame5(&w, v)
+	easyjson85f0d656EncodeGameGame22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PlayerData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame5(w, v)
+func (v GameOverInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PlayerData) UnmarshalJSON(data []byte) error {
+func (v *GameOverInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame5(&r, v)
+	easyjson85f0d656DecodeGameGame22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PlayerData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame5(l, v)
+func (v *GameOverInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame22(l, v)
 }
-func easyjson85f0d656DecodeGameGame6(in *jlexer.Lexer, out *GotMessage) {
+func easyjson85f0d656DecodeGameGame23(in *jlexer.Lexer, out *ChangedTeam) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -783,8 +3371,18 @@ func easyjson85f0d656DecodeGameGame6(in *jlexer.Lexer, out *GotMessage) {
 			continue
 		}
 		switch key {
-		case "actions":
-			out.Actions = Actions(in.Int())
+		case "num":
+			out.Num = int(in.Int())
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				if out.Data == nil {
+					out.Data = new(TeamData)
+				}
+				(*out.Data).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -795,47 +3393,61 @@ func easyjson85f0d656DecodeGameGame6(in *jlexer.Lexer, out *GotMessage) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame6(out *jwriter.Writer, in GotMessage) {
+func easyjson85f0d656EncodeGameGame23(out *jwriter.Writer, in ChangedTeam) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"actions\":"
+		const prefix string = ",\"num\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.Actions))
+		out.Int(int(in.Num))
+	}
+	{
+		const prefix string = ",\"data\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Data == nil {
+			out.RawString("null")
+		} else {
+			(*in.Data).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v GotMessage) MarshalJSON() ([]byte, error) {
+func (v ChangedTeam) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame6(&w, v)
+	easyjson85f0d656EncodeGameGame23(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v GotMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame6(w, v)
+func (v ChangedTeam) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame23(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *GotMessage) UnmarshalJSON(data []byte) error {
+func (v *ChangedTeam) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame6(&r, v)
+	easyjson85f0d656DecodeGameGame23(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *GotMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame6(l, v)
+func (v *ChangedTeam) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame23(l, v)
 }
-func easyjson85f0d656DecodeGameGame7(in *jlexer.Lexer, out *Const) {
+func easyjson85f0d656DecodeGameGame24(in *jlexer.Lexer, out *ChangedPlayer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -854,8 +3466,18 @@ func easyjson85f0d656DecodeGameGame7(in *jlexer.Lexer, out *Const) {
 			continue
 		}
 		switch key {
-		case "gameTime":
-			out.GameTime = time.Duration(in.Int64())
+		case "num":
+			out.Num = int(in.Int())
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				if out.Data == nil {
+					out.Data = new(PlayerData)
+				}
+				(*out.Data).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -866,43 +3488,57 @@ func easyjson85f0d656DecodeGameGame7(in *jlexer.Lexer, out *Const) {
 		in.Consumed()
 	}
 }
-func easyjson85f0d656EncodeGameGame7(out *jwriter.Writer, in Const) {
+func easyjson85f0d656EncodeGameGame24(out *jwriter.Writer, in ChangedPlayer) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"gameTime\":"
+		const prefix string = ",\"num\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int64(int64(in.GameTime))
+		out.Int(int(in.Num))
+	}
+	{
+		const prefix string = ",\"data\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Data == nil {
+			out.RawString("null")
+		} else {
+			(*in.Data).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Const) MarshalJSON() ([]byte, error) {
+func (v ChangedPlayer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson85f0d656EncodeGameGame7(&w, v)
+	easyjson85f0d656EncodeGameGame24(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Const) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson85f0d656EncodeGameGame7(w, v)
+func (v ChangedPlayer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85f0d656EncodeGameGame24(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Const) UnmarshalJSON(data []byte) error {
+func (v *ChangedPlayer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson85f0d656DecodeGameGame7(&r, v)
+	easyjson85f0d656DecodeGameGame24(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Const) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson85f0d656DecodeGameGame7(l, v)
+func (v *ChangedPlayer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85f0d656DecodeGameGame24(l, v)
 }
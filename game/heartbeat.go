@@ -0,0 +1,70 @@
+package game
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+const (
+	PongWait   = 10 * time.Second  // connection without pongs and messages for this time is dead
+	PingPeriod = PongWait * 9 / 10 // must be less than PongWait
+	WriteWait  = 1 * time.Second   // time for writing ping
+)
+
+// heartbeat pings the player until his connection is dropped or broken.
+// Listen reads pongs and extends the read deadline.
+func (p *Player) heartbeat() {
+	ticker := time.NewTicker(PingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := p.UserInfo.Conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WriteWait))
+			if err != nil {
+				return
+			}
+		case <-p.Ctx.Done():
+			return
+		}
+	}
+}
+
+// evict removes the player waiting for the game from the matchmaking queue or from
+// the private room. The room left without players is closed. The player whose connection
+// is dead has empty status, he is disconnected as usual if the game has already started.
+// Otherwise the player gets the status, it is too late to leave the started game.
+func (g *Game) evict(p *Player, status string) {
+	if g.Matchmaker.Remove(p) {
+		logger.Infof("player %v (game session %v) evicted from the queue", p.UserInfo.UID, p.GameSessionID)
+		p.sendAway(status)
+		return
+	}
+	r := p.room()
+	if r == nil || g.leavePrivateRoom(r, p, status) || status != "" {
+		return
+	}
+	go func() {
+		select {
+		case r.Unregister <- p:
+		case <-r.Ctx.Done():
+		}
+	}()
+}
+
+// sendAway stops the player waiting for the game and sends him the status,
+// the connection is just closed if the status is empty. The status is written
+// after Send of the player in private room exits.
+func (p *Player) sendAway(status string) {
+	if status == "" {
+		p.dropConn()
+		return
+	}
+	p.cancel()
+	go func() {
+		p.sending.Wait()
+		rejectUser(p.UserInfo, status)
+	}()
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
+)
+
+// InputVersion is the current version of messages from client. Version 1 (or no version)
+// is the legacy "actions" field with decimal mask: hundreds are left, tens are jump
+// and ones are right (110 is left and jump). Since version 2 actions are sent in "input".
+const InputVersion = 2
+
+// CancelAction is the action of the player who stops waiting for the game.
+const CancelAction = "cancel"
+
+// action flags of Actions
+const (
+	ActionRight Actions = 1 << iota
+	ActionJump
+	ActionLeft
+
+	knownActions = ActionRight | ActionJump | ActionLeft
+)
+
+// Input is the state of controls sent by client. New actions are added as new fields.
+//
+//easyjson:json
+type Input struct {
+	Left  bool `json:"left,omitempty"`
+	Right bool `json:"right,omitempty"`
+	Jump  bool `json:"jump,omitempty"`
+}
+
+//easyjson:json
+type ProtocolError struct {
+	Error string `json:"error"`
+}
+
+// Has checks if all the flags are set.
+func (a Actions) Has(flags Actions) bool {
+	return a&flags == flags
+}
+
+// Validate checks if only known flags are set.
+func (a Actions) Validate() error {
+	if a&^knownActions != 0 {
+		return ErrUnknownActions
+	}
+	return nil
+}
+
+// actions returns flags of the input.
+func (in *Input) actions() Actions {
+	var a Actions
+	if in.Left {
+		a |= ActionLeft
+	}
+	if in.Right {
+		a |= ActionRight
+	}
+	if in.Jump {
+		a |= ActionJump
+	}
+	return a
+}
+
+// legacyActions converts decimal mask of InputVersion 1 to flags.
+func legacyActions(mask int) (Actions, error) {
+	var a Actions
+	for _, flag := range []Actions{ActionRight, ActionJump, ActionLeft} {
+		switch mask % 10 {
+		case 0:
+		case 1:
+			a |= flag
+		default:
+			return 0, ErrUnknownActions
+		}
+		mask /= 10
+	}
+	if mask != 0 {
+		return 0, ErrUnknownActions
+	}
+	return a, nil
+}
+
+// actions returns validated action flags of the message according to its version.
+func (m *GotMessage) actions() (Actions, error) {
+	switch {
+	case m.Version > InputVersion || m.Version < 0:
+		return 0, ErrUnsupportedVersion
+	case m.Version < 2:
+		return legacyActions(m.Actions)
+	case m.Input == nil: // message without input, for example ack
+		return 0, nil
+	}
+	a := m.Input.actions()
+	return a, a.Validate()
+}
+
+// protocolError tells the player that his message is not valid.
+func (p *Player) protocolError(err error) {
+	logger.Infof("protocol error from player %v (game session %v): %v", p.UserInfo.UID, p.GameSessionID, err)
+	select {
+	case p.SendMessage <- &WSMessageToSend{
+		Status: "protocol_error",
+		Payload: &ProtocolError{
+			Error: err.Error(),
+		},
+	}:
+	default: // player doesn't read messages anyway
+		metrics.AddDroppedMessage("protocol_error")
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import "testing"
+
+func TestLegacyActions(t *testing.T) {
+	tests := []struct {
+		mask    int
+		actions Actions
+		err     error
+	}{
+		{0, 0, nil},
+		{1, ActionRight, nil},
+		{10, ActionJump, nil},
+		{100, ActionLeft, nil},
+		{110, ActionLeft | ActionJump, nil},
+		{111, ActionLeft | ActionJump | ActionRight, nil},
+		{2, 0, ErrUnknownActions},
+		{120, 0, ErrUnknownActions},
+		{1000, 0, ErrUnknownActions},
+		{-1, 0, ErrUnknownActions},
+	}
+	for _, tt := range tests {
+		a, err := legacyActions(tt.mask)
+		if err != tt.err {
+			t.Errorf("mask %v: got error %v, want %v", tt.mask, err, tt.err)
+			continue
+		}
+		if err == nil && a != tt.actions {
+			t.Errorf("mask %v: got actions %v, want %v", tt.mask, a, tt.actions)
+		}
+	}
+}
+
+func TestGotMessageActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *GotMessage
+		actions Actions
+		err     error
+	}{
+		{"legacy without version", &GotMessage{Actions: 11}, ActionJump | ActionRight, nil},
+		{"legacy version 1", &GotMessage{Version: 1, Actions: 100}, ActionLeft, nil},
+		{"input", &GotMessage{Version: 2, Input: &Input{Left: true, Jump: true}}, ActionLeft | ActionJump, nil},
+		{"ack without input", &GotMessage{Version: 2, Ack: 5}, 0, nil},
+		{"future version", &GotMessage{Version: 3, Input: &Input{Left: true}}, 0, ErrUnsupportedVersion},
+		{"negative version", &GotMessage{Version: -1}, 0, ErrUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		a, err := tt.m.actions()
+		if err != tt.err {
+			t.Errorf("%v: got error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if a != tt.actions {
+			t.Errorf("%v: got actions %v, want %v", tt.name, a, tt.actions)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+// loggerConfig makes the logger write only errors to stderr during tests.
+const loggerConfig = `{
+	"level": "error",
+	"encoding": "console",
+	"outputPaths": ["stderr"],
+	"errorOutputPaths": ["stderr"],
+	"encoderConfig": {"messageKey": "message"}
+}`
+
+// TestMain initializes the logger: it reads its config from ./logger relative to
+// working directory, so the config is written to temporary directory.
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "game-test")
+	if err != nil {
+		panic(err)
+	}
+	err = os.MkdirAll(filepath.Join(dir, "logger"), 0700)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, "logger", "logger-config.json"), []byte(loggerConfig), 0600)
+	}
+	if err != nil {
+		panic(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Chdir(dir)
+	logger.InitLogger()
+	_ = os.Chdir(wd)
+	_ = os.RemoveAll(dir)
+
+	os.Exit(m.Run())
+}
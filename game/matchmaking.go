@@ -0,0 +1,221 @@
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRating = 1500
+
+	MatchmakingEvery        = 1 * time.Second
+	MatchmakingBaseGap      = 100 // allowed rating gap for just queued player
+	MatchmakingGapPerSecond = 25  // gap widening per second of waiting
+	MatchmakingMaxGap       = 1000
+	DefaultMatchTimeout     = 2 * time.Minute // wait in the queue before player gets "no_opponent"
+)
+
+// Ticket is a player waiting in matchmaking queue.
+type Ticket struct {
+	Player *Player
+	Mode   string
+	Rating int
+	Since  time.Time
+}
+
+// Matchmaker keeps the queue of waiting players and pairs them by rating.
+type Matchmaker struct {
+	queue  []*Ticket
+	queueM *sync.Mutex
+}
+
+// allowedGap returns the rating gap the ticket accepts at the moment now.
+// The longer player waits the wider gap is.
+func (t *Ticket) allowedGap(now time.Time) int {
+	gap := MatchmakingBaseGap + int(now.Sub(t.Since).Seconds()*MatchmakingGapPerSecond)
+	if gap > MatchmakingMaxGap {
+		return MatchmakingMaxGap
+	}
+	return gap
+}
+
+// Push adds ticket to the end of the queue.
+func (m *Matchmaker) Push(t *Ticket) {
+	m.queueM.Lock()
+	m.queue = append(m.queue, t)
+	m.queueM.Unlock()
+}
+
+// PushUnique adds ticket to the end of the queue if the user is not waiting
+// in the queue yet, otherwise ErrIsPlaying is returned.
+func (m *Matchmaker) PushUnique(t *Ticket) error {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	for _, q := range m.queue {
+		if q.Player.UserInfo.UID == t.Player.UserInfo.UID {
+			return ErrIsPlaying
+		}
+	}
+	m.queue = append(m.queue, t)
+	return nil
+}
+
+// Contains checks if user with given uID is waiting in the queue.
+func (m *Matchmaker) Contains(uID uint) bool {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	for _, t := range m.queue {
+		if t.Player.UserInfo.UID == uID {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes the ticket of the player from the queue.
+// Returns false if the player is not waiting in the queue.
+func (m *Matchmaker) Remove(p *Player) bool {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	for i, t := range m.queue {
+		if t.Player == p {
+			copy(m.queue[i:], m.queue[i+1:])
+			m.queue[len(m.queue)-1] = nil
+			m.queue = m.queue[:len(m.queue)-1]
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns count of waiting players.
+func (m *Matchmaker) Len() int {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	return len(m.queue)
+}
+
+// Drain removes all the tickets from the queue and returns them.
+func (m *Matchmaker) Drain() []*Ticket {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	tickets := m.queue
+	m.queue = make([]*Ticket, 0, 16)
+	return tickets
+}
+
+// TakeWaiting removes the tickets of the game mode waiting longer than wait
+// from the queue and returns them.
+func (m *Matchmaker) TakeWaiting(now time.Time, mode string, wait time.Duration) []*Ticket {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+	taken := make([]*Ticket, 0)
+	rest := m.queue[:0]
+	for _, t := range m.queue {
+		if t.Mode == mode && now.Sub(t.Since) >= wait {
+			taken = append(taken, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	for i := len(rest); i < len(m.queue); i++ {
+		m.queue[i] = nil
+	}
+	m.queue = rest
+	return taken
+}
+
+// Match groups waiting players of the game mode by size so that the rating spread of every
+// group fits into allowed gaps of all its members and removes them from the queue.
+// Players who wait longer are matched first. A user is never matched with himself.
+func (m *Matchmaker) Match(now time.Time, mode string, size int) [][]*Ticket {
+	m.queueM.Lock()
+	defer m.queueM.Unlock()
+
+	sort.SliceStable(m.queue, func(i, j int) bool {
+		return m.queue[i].Since.Before(m.queue[j].Since)
+	})
+	matched := make([]bool, len(m.queue))
+	groups := make([][]*Ticket, 0, len(m.queue)/size)
+	for i, t := range m.queue {
+		if matched[i] || t.Mode != mode {
+			continue
+		}
+		// candidates with the closest rating are tried first
+		candidates := make([]int, 0, len(m.queue)-i-1)
+		for j := i + 1; j < len(m.queue); j++ {
+			if !matched[j] && m.queue[j].Mode == mode {
+				candidates = append(candidates, j)
+			}
+		}
+		sort.SliceStable(candidates, func(a, b int) bool {
+			return abs(t.Rating-m.queue[candidates[a]].Rating) < abs(t.Rating-m.queue[candidates[b]].Rating)
+		})
+		group := []int{i}
+		minRating, maxRating, gap := t.Rating, t.Rating, t.allowedGap(now)
+		for _, j := range candidates {
+			if len(group) == size {
+				break
+			}
+			c := m.queue[j]
+			if inGroup(m.queue, group, c.Player.UserInfo.UID) {
+				continue
+			}
+			newMin, newMax := min(minRating, c.Rating), max(maxRating, c.Rating)
+			newGap := min(gap, c.allowedGap(now))
+			if newMax-newMin > newGap {
+				continue
+			}
+			group = append(group, j)
+			minRating, maxRating, gap = newMin, newMax, newGap
+		}
+		if len(group) < size {
+			continue
+		}
+		tickets := make([]*Ticket, 0, size)
+		for _, j := range group {
+			matched[j] = true
+			tickets = append(tickets, m.queue[j])
+		}
+		groups = append(groups, tickets)
+	}
+
+	rest := m.queue[:0]
+	for i, t := range m.queue {
+		if !matched[i] {
+			rest = append(rest, t)
+		}
+	}
+	for i := len(rest); i < len(m.queue); i++ {
+		m.queue[i] = nil
+	}
+	m.queue = rest
+
+	return groups
+}
+
+// inGroup checks if the user with given uID has a ticket in the group of queue indexes.
+func inGroup(queue []*Ticket, group []int, uID uint) bool {
+	for _, i := range group {
+		if queue[i].Player.UserInfo.UID == uID {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMatchmaker initializes new object of Matchmaker with empty queue.
+func NewMatchmaker() *Matchmaker {
+	return &Matchmaker{
+		queue:  make([]*Ticket, 0, 16),
+		queueM: &sync.Mutex{},
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func newTicket(uID uint, mode string, rating int, since time.Time) *Ticket {
+	return &Ticket{
+		Player: &Player{
+			UserInfo: &User{UID: uID},
+		},
+		Mode:   mode,
+		Rating: rating,
+		Since:  since,
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		tickets []*Ticket
+		mode    string
+		size    int
+		groups  [][]uint // UIDs of matched groups
+		left    int
+	}{
+		{
+			name: "close ratings",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now),
+				newTicket(2, ModeSolo, 1550, now),
+			},
+			mode:   ModeSolo,
+			size:   2,
+			groups: [][]uint{{1, 2}},
+		},
+		{
+			name: "far ratings of just queued players",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now),
+				newTicket(2, ModeSolo, 1800, now),
+			},
+			mode: ModeSolo,
+			size: 2,
+			left: 2,
+		},
+		{
+			name: "far ratings after long waiting",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now.Add(-time.Minute)),
+				newTicket(2, ModeSolo, 1800, now.Add(-time.Minute)),
+			},
+			mode:   ModeSolo,
+			size:   2,
+			groups: [][]uint{{1, 2}},
+		},
+		{
+			name: "the closest rating first",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now.Add(-time.Second)),
+				newTicket(2, ModeSolo, 1590, now),
+				newTicket(3, ModeSolo, 1510, now),
+			},
+			mode:   ModeSolo,
+			size:   2,
+			groups: [][]uint{{1, 3}},
+			left:   1,
+		},
+		{
+			name: "other mode is ignored",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now),
+				newTicket(2, ModeTeam, 1500, now),
+			},
+			mode: ModeSolo,
+			size: 2,
+			left: 2,
+		},
+		{
+			name: "user is not matched with himself",
+			tickets: []*Ticket{
+				newTicket(1, ModeSolo, 1500, now),
+				newTicket(1, ModeSolo, 1500, now),
+			},
+			mode: ModeSolo,
+			size: 2,
+			left: 2,
+		},
+		{
+			name: "team mode",
+			tickets: []*Ticket{
+				newTicket(1, ModeTeam, 1500, now),
+				newTicket(2, ModeTeam, 1520, now),
+				newTicket(3, ModeTeam, 1540, now),
+				newTicket(4, ModeTeam, 1560, now),
+				newTicket(5, ModeTeam, 1580, now),
+			},
+			mode:   ModeTeam,
+			size:   4,
+			groups: [][]uint{{1, 2, 3, 4}},
+			left:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchmaker()
+			for _, ticket := range tt.tickets {
+				m.Push(ticket)
+			}
+			groups := m.Match(now, tt.mode, tt.size)
+			if len(groups) != len(tt.groups) {
+				t.Fatalf("got %v groups, want %v", len(groups), len(tt.groups))
+			}
+			for i, group := range groups {
+				if len(group) != len(tt.groups[i]) {
+					t.Fatalf("group %v has %v players, want %v", i, len(group), len(tt.groups[i]))
+				}
+				for j, ticket := range group {
+					if ticket.Player.UserInfo.UID != tt.groups[i][j] {
+						t.Errorf("group %v player %v is %v, want %v", i, j, ticket.Player.UserInfo.UID, tt.groups[i][j])
+					}
+				}
+			}
+			if m.Len() != tt.left {
+				t.Errorf("%v players left in the queue, want %v", m.Len(), tt.left)
+			}
+		})
+	}
+}
+
+func TestPushUnique(t *testing.T) {
+	m := NewMatchmaker()
+	now := time.Now()
+	if err := m.PushUnique(newTicket(1, ModeSolo, 1500, now)); err != nil {
+		t.Fatalf("first ticket: %v", err)
+	}
+	if err := m.PushUnique(newTicket(1, ModeTeam, 1500, now)); err != ErrIsPlaying {
+		t.Errorf("second ticket of the user: got %v, want %v", err, ErrIsPlaying)
+	}
+	if m.Len() != 1 {
+		t.Errorf("%v players in the queue, want 1", m.Len())
+	}
+}
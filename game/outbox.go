@@ -0,0 +1,188 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/database"
+	"game/metrics"
+	"game/models"
+)
+
+const (
+	ResultsRetryEvery = 10 * time.Second
+	MaxResultAttempts = 5 // failed writes to reachable database before results are moved to FailedDir
+
+	FailedDir = "failed" // subdirectory of the outbox for results which can never be written
+)
+
+// Outbox is a durable on-disk queue of match results: every result is put to the outbox
+// before writing to database and removed only after successful write, so results
+// survive database failures and service restarts.
+type Outbox struct {
+	dir string
+
+	attempts  map[string]int // failed writes by room ID, not counted while database is down
+	attemptsM *sync.Mutex
+}
+
+// Put writes the match results to the outbox.
+func (o *Outbox) Put(m *models.Match) error {
+	j, err := m.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	// rename is atomic so the outbox never has partially written results
+	tmp := filepath.Join(o.dir, m.RoomID+".tmp")
+	err = ioutil.WriteFile(tmp, j, 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, o.path(m.RoomID))
+	if err != nil {
+		return err
+	}
+	metrics.AddPendingResult()
+	return nil
+}
+
+// Remove removes the match results with given room ID from the outbox.
+func (o *Outbox) Remove(roomID string) error {
+	err := os.Remove(o.path(roomID))
+	if err != nil {
+		return err
+	}
+	o.forget(roomID)
+	metrics.SubtractPendingResult()
+	return nil
+}
+
+// fail counts the failed write of the match results and returns count of failed writes.
+func (o *Outbox) fail(roomID string) int {
+	o.attemptsM.Lock()
+	defer o.attemptsM.Unlock()
+	o.attempts[roomID]++
+	return o.attempts[roomID]
+}
+
+func (o *Outbox) forget(roomID string) {
+	o.attemptsM.Lock()
+	delete(o.attempts, roomID)
+	o.attemptsM.Unlock()
+}
+
+// Quarantine moves the match results which can never be written to FailedDir,
+// they are not retried anymore and wait for manual fixing.
+func (o *Outbox) Quarantine(roomID string) error {
+	err := os.Rename(o.path(roomID), filepath.Join(o.dir, FailedDir, roomID+".json"))
+	if err != nil {
+		return err
+	}
+	o.forget(roomID)
+	metrics.SubtractPendingResult()
+	metrics.AddQuarantinedResult()
+	return nil
+}
+
+// Pending returns all the match results from the outbox.
+func (o *Outbox) Pending() ([]*models.Match, error) {
+	files, err := filepath.Glob(filepath.Join(o.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*models.Match, 0, len(files))
+	for _, f := range files {
+		j, err := ioutil.ReadFile(f)
+		if err != nil {
+			logger.Errorf("failed to read results from outbox %v: %v", f, err)
+			continue
+		}
+		m := &models.Match{}
+		err = m.UnmarshalJSON(j)
+		if err != nil {
+			logger.Errorf("failed to parse results from outbox %v: %v", f, err)
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+func (o *Outbox) path(roomID string) string {
+	return filepath.Join(o.dir, roomID+".json")
+}
+
+// NewOutbox initializes new object of Outbox in the directory dir (creates it if needed).
+func NewOutbox(dir string) (*Outbox, error) {
+	err := os.MkdirAll(filepath.Join(dir, FailedDir), 0700)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), ".json") {
+			metrics.AddPendingResult()
+		}
+	}
+	return &Outbox{
+		dir:       dir,
+		attempts:  make(map[string]int),
+		attemptsM: &sync.Mutex{},
+	}, nil
+}
+
+// writeResults writes the match results to database and removes them from the outbox on success.
+func (g *Game) writeResults(m *models.Match) bool {
+	err := database.SaveMatchResults(g.dm, m)
+	if err != nil {
+		metrics.FailedResultWrites.Inc()
+		logger.Errorf("failed to save results of room %v: %v", m.RoomID, err)
+		return false
+	}
+	err = g.outbox.Remove(m.RoomID)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Errorf("failed to remove results of room %v from outbox: %v", m.RoomID, err)
+	}
+	return true
+}
+
+// retryResults tries to write results from the outbox to database every ResultsRetryEvery.
+// Results failed MaxResultAttempts times while database is reachable are moved to FailedDir.
+func (g *Game) retryResults() {
+	ticker := time.NewTicker(ResultsRetryEvery)
+	defer ticker.Stop()
+	for range ticker.C {
+		pending, err := g.outbox.Pending()
+		if err != nil {
+			logger.Errorf("failed to read outbox: %v", err)
+			continue
+		}
+		for _, m := range pending {
+			if g.writeResults(m) {
+				logger.Infof("saved results of room %v from outbox", m.RoomID)
+				continue
+			}
+			if database.Ping(g.dm) != nil { // database is down, the results are not to blame
+				break
+			}
+			if g.outbox.fail(m.RoomID) < MaxResultAttempts {
+				continue
+			}
+			err = g.outbox.Quarantine(m.RoomID)
+			if err != nil {
+				logger.Errorf("failed to move results of room %v to %v: %v", m.RoomID, FailedDir, err)
+				continue
+			}
+			logger.Errorf("results of room %v failed %v times, moved to %v", m.RoomID, MaxResultAttempts, FailedDir)
+		}
+	}
+}
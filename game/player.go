@@ -1,25 +1,54 @@
 package game
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
 )
 
 type User struct {
 	SessionID string
 	UID       uint
+	Mode      string // game mode chosen by user
+	Private   bool   // user creates private room
+	Code      string // invite code of private room to join
+	Bot       string // difficulty level of bot to play with right away
+	Rate      int    // states per second client wants to get, 0 for rate of the room
 	Conn      *websocket.Conn
 }
 
 type Player struct {
+	acked      int64 // atomic, version of the last state received by client
+	violations int32 // atomic, count of anti-cheat violations
+
 	UserInfo *User
 
 	GameSessionID string
-	Room          *Room
+	Room          *Room // set with setRoom, read with room while Listen may run
+	roomM         *sync.Mutex
+	Rating        int
+
+	// Ctx is done when the connection of player is dropped by the room
+	Ctx    context.Context
+	cancel func()
+
+	sending sync.WaitGroup // Send is running, see startSend
+
+	disconnected   bool // waits for reconnect
+	reconnectTimer *time.Timer
+
+	bot *Bot // not nil if the player is controlled by server
+
+	limiter   rateLimiter  // used by Listen only
+	dropped   int          // states dropped in a row, used by room only
+	collected []PointsData // points of versions not sent to the player yet, used by room only
 
 	SendMessage chan *WSMessageToSend
 }
@@ -27,22 +56,37 @@ type Player struct {
 //easyjson:json
 // GotMessage is a message from client with hero Action: move `LEFT`, `RIGHT` or `JUMP`.
 type GotMessage struct {
-	Actions Actions `json:"actions"`
+	Version int    `json:"v,omitempty"`       // InputVersion of the message, 1 if not set
+	Actions int    `json:"actions,omitempty"` // decimal mask of version 1
+	Input   *Input `json:"input,omitempty"`   // since version 2
+	Seq     int    `json:"seq,omitempty"`     // number of input, increases with every message with actions
+	Ack     int    `json:"ack,omitempty"`     // version of the last state received
+	Resync  bool   `json:"resync,omitempty"`  // client missed states and asks for the full one
+	Action  string `json:"action,omitempty"`  // CancelAction while waiting for the game
 }
 
 type ProcessActions struct {
 	From string
+	Seq  int
 	Actions
 }
 
 // Listen reads messages from player and breaks the loop when player disconnects or game in room ended.
+// The player waiting for the game may only cancel the search. The connection is dead if there are
+// no messages or pongs for PongWait.
 func (p *Player) Listen() {
+	conn := p.UserInfo.Conn
+	_ = conn.SetReadDeadline(time.Now().Add(PongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
 	for {
 		m := &GotMessage{}
-		_, raw, err := p.UserInfo.Conn.ReadMessage()
+		_, raw, err := conn.ReadMessage()
+		r := p.room()
 		if err != nil {
-			if p.Room.Ctx.Err() != nil {
-				logger.Debugf("killed listen player %v at room %v", p.GameSessionID, p.Room.ID)
+			if p.Ctx.Err() != nil || r != nil && r.Ctx.Err() != nil {
+				logger.Debugf("killed listen player %v", p.GameSessionID)
 				return
 			}
 			if websocket.IsUnexpectedCloseError(err) {
@@ -50,59 +94,142 @@ func (p *Player) Listen() {
 			} else {
 				logger.Error(err)
 			}
-			p.Room.Unregister <- p
+			if r == nil || !r.isStarted() {
+				g.Leave <- p
+				return
+			}
+			r.Unregister <- p
 			return
 		}
+		_ = conn.SetReadDeadline(time.Now().Add(PongWait))
+		if r == nil || !r.isStarted() {
+			// Listen goes on if the game starts before the cancel is processed
+			if m.UnmarshalJSON(raw) == nil && m.Action == CancelAction {
+				g.Cancel <- p
+			}
+			continue
+		}
 		err = m.UnmarshalJSON(raw)
 		if err != nil {
-			logger.Error(err)
+			p.protocolError(ErrBadMessage)
+			continue
+		}
+		actions, err := m.actions()
+		if err != nil {
+			if err == ErrUnknownActions {
+				metrics.AddUnknownActions()
+			}
+			p.protocolError(err)
+			continue
+		}
+		ok, violation := p.limiter.allow(time.Now(), r.rules.maxMessagesPerSecond())
+		if violation && p.violate(ViolationRate) {
+			select {
+			case r.kicks <- p:
+			case <-r.Ctx.Done():
+			}
+		}
+		if !ok {
 			continue
 		}
-		logger.Debugf("got correct message with action %v from %v", m.Actions, p.GameSessionID)
+		logger.Debugf("got correct message with action %v from %v", actions, p.GameSessionID)
 
-		if p.Room.engine != nil {
-			p.Room.engine.Update <- &ProcessActions{
-				From:    p.GameSessionID,
-				Actions: m.Actions,
+		if m.Ack != 0 || m.Resync {
+			p.ack(m.Ack, m.Resync)
+			if actions == 0 { // nothing to do for the hero
+				continue
 			}
 		}
+		metrics.AddReceivedInput()
+		r.engine.Update <- &ProcessActions{
+			From:    p.GameSessionID,
+			Seq:     m.Seq,
+			Actions: actions,
+		}
 	}
 }
 
+// startSend runs Send. Connection supports only one writer at a time, so the last write
+// to the connection after the player is stopped should wait for Send to exit.
+func (p *Player) startSend() {
+	p.sending.Add(1)
+	go p.Send()
+}
+
 // Send writes every message from SendMessage channel to player and breaks the loop when game in room ends.
+// It should be run with startSend.
 func (p *Player) Send() {
+	defer p.sending.Done()
 	for {
 		select {
 		case m := <-p.SendMessage:
-			j, err := m.MarshalJSON()
+			t, data, err := encode(p.UserInfo.Conn, m)
 			if err != nil {
 				logger.Error(err)
 				continue
 			}
 			// kick players with low network
-			_ = p.UserInfo.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
-			err = p.UserInfo.Conn.WriteMessage(websocket.TextMessage, j)
+			start := time.Now()
+			_ = p.UserInfo.Conn.SetWriteDeadline(start.Add(1 * time.Second))
+			err = p.UserInfo.Conn.WriteMessage(t, data)
+			metrics.ObserveWrite(time.Since(start))
 			if err != nil {
+				if p.Ctx.Err() != nil {
+					return
+				}
 				if websocket.IsUnexpectedCloseError(err) {
 					logger.Infof("send: player %v was disconnected (game session %v)", p.UserInfo.UID, p.GameSessionID)
 				} else {
 					logger.Error(err)
 				}
-				p.Room.Unregister <- p
+				select {
+				case p.Room.Unregister <- p:
+				case <-p.Room.Ctx.Done():
+				case <-p.Ctx.Done():
+				}
 				return
 			}
 		case <-p.Room.Ctx.Done():
 			logger.Debugf("killed send to player %v at room %v", p.GameSessionID, p.Room.ID)
 			return
+		case <-p.Ctx.Done():
+			logger.Debugf("killed send to dropped player %v at room %v", p.GameSessionID, p.Room.ID)
+			return
 		}
 	}
 }
 
+// room returns the room of the player, nil if he waits in the queue.
+func (p *Player) room() *Room {
+	p.roomM.Lock()
+	defer p.roomM.Unlock()
+	return p.Room
+}
+
+func (p *Player) setRoom(r *Room) {
+	p.roomM.Lock()
+	p.Room = r
+	p.roomM.Unlock()
+}
+
+// dropConn stops Listen and Send of player and closes his connection.
+func (p *Player) dropConn() {
+	p.cancel()
+	if p.isBot() { // bot has no connection
+		return
+	}
+	p.UserInfo.Conn.Close()
+}
+
 // NewPlayer initializes new object of Player with given User.
 func NewPlayer(u *User) *Player {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Player{
 		UserInfo:      u,
 		GameSessionID: uuid.NewV4().String(),
-		SendMessage:   make(chan *WSMessageToSend, 100),
+		roomM:         &sync.Mutex{},
+		Ctx:           ctx,
+		cancel:        cancel,
+		SendMessage:   make(chan *WSMessageToSend, SendBuffer),
 	}
 }
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+const (
+	InviteCodeLength   = 6
+	InviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // without similar looking characters
+
+	PrivateRoomTimeout = 5 * time.Minute // time for friends to join the private room
+)
+
+//easyjson:json
+type InviteInfo struct {
+	Code string `json:"code"`
+	Mode string `json:"mode"`
+}
+
+// newInviteCode returns random invite code which is not used by other private rooms.
+// Must be called with invitesM locked.
+func (g *Game) newInviteCode() (string, error) {
+	max := big.NewInt(int64(len(InviteCodeAlphabet)))
+	for {
+		code := make([]byte, InviteCodeLength)
+		for i := range code {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			code[i] = InviteCodeAlphabet[n.Int64()]
+		}
+		if _, ok := g.invites[string(code)]; !ok {
+			return string(code), nil
+		}
+	}
+}
+
+// createPrivateRoom creates the room of the game mode held out of matchmaking, puts the player
+// into it and sends him the invite code. The room is closed if it is not full in PrivateRoomTimeout.
+func (g *Game) createPrivateRoom(p *Player, mode string) error {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	code, err := g.newInviteCode()
+	if err != nil {
+		return err
+	}
+	r, err := g.createRoom(mode)
+	if err != nil {
+		return err
+	}
+	r.code = code
+	g.invites[code] = r
+	r.inviteTimer = time.AfterFunc(PrivateRoomTimeout, func() {
+		g.expirePrivateRoom(r)
+	})
+	g.joinRoom(r, p)
+	p.SendMessage <- &WSMessageToSend{
+		Status: "private_room",
+		Payload: &InviteInfo{
+			Code: code,
+			Mode: mode,
+		},
+	}
+	logger.Infof("player %v created private room %v with code %v", p.UserInfo.UID, r.ID, code)
+
+	return nil
+}
+
+// joinPrivateRoom puts the player into the private room with given invite code.
+// The game starts when the room is full.
+func (g *Game) joinPrivateRoom(p *Player, code string) error {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	r, ok := g.invites[code]
+	if !ok {
+		return ErrBadInviteCode
+	}
+	g.joinRoom(r, p)
+	p.SendMessage <- &WSMessageToSend{
+		Status: "private_room",
+		Payload: &InviteInfo{
+			Code: code,
+			Mode: r.mode,
+		},
+	}
+	if r.Total < g.roomSize(r.mode) {
+		return nil
+	}
+
+	delete(g.invites, code)
+	r.inviteTimer.Stop()
+	r.broadcast(&WSMessageToSend{
+		Status: "matched",
+	})
+	go r.Run()
+
+	return nil
+}
+
+// expirePrivateRoom closes the private room if nobody has joined it in time.
+// Players in the room get status "invite_expired".
+func (g *Game) expirePrivateRoom(r *Room) {
+	g.invitesM.Lock()
+	_, ok := g.invites[r.code]
+	delete(g.invites, r.code)
+	g.invitesM.Unlock()
+	if !ok { // the game has already started
+		return
+	}
+	logger.Infof("private room %v with code %v expired", r.ID, r.code)
+	g.closePrivateRoom(r, "invite_expired")
+}
+
+// takePrivateRoom stops waiting for players in the private room, so nobody
+// can join it. Returns false if the room is not waiting.
+func (g *Game) takePrivateRoom(r *Room) bool {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	if r.code == "" || g.invites[r.code] != r {
+		return false
+	}
+	delete(g.invites, r.code)
+	r.inviteTimer.Stop()
+	return true
+}
+
+// closePrivateRoom sends the status to the players waiting in the private room,
+// disconnects them and removes the room.
+func (g *Game) closePrivateRoom(r *Room, status string) {
+	r.cancel()
+	r.Players.Range(func(k, v interface{}) bool {
+		v.(*Player).sendAway(status)
+		return true
+	})
+	g.removeRoom(r)
+}
+
+// leavePrivateRoom sends away the player from the private room waiting for players
+// and closes the room if it is empty. Returns false if the room is not waiting.
+func (g *Game) leavePrivateRoom(r *Room, p *Player, status string) bool {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	if r.code == "" || g.invites[r.code] != r {
+		return false
+	}
+	r.Players.Delete(p.GameSessionID)
+	r.TotalM.Lock()
+	r.Total--
+	total := r.Total
+	r.TotalM.Unlock()
+	p.sendAway(status)
+	logger.Infof("player %v (game session %v) evicted from private room %v", p.UserInfo.UID, p.GameSessionID, r.ID)
+	if total > 0 {
+		return true
+	}
+	delete(g.invites, r.code)
+	r.inviteTimer.Stop()
+	r.cancel()
+	g.removeRoom(r)
+	return true
+}
+
+// sendAwayPrivate closes all the private rooms waiting for players
+// and returns their count.
+func (g *Game) sendAwayPrivate() int {
+	g.invitesM.Lock()
+	rooms := make([]*Room, 0, len(g.invites))
+	for code, r := range g.invites {
+		r.inviteTimer.Stop()
+		rooms = append(rooms, r)
+		delete(g.invites, code)
+	}
+	g.invitesM.Unlock()
+	for _, r := range rooms {
+		g.closePrivateRoom(r, "server_shutdown")
+	}
+	return len(rooms)
+}
+
+// isWaitingPrivate checks if the room is private and waits for players.
+func (g *Game) isWaitingPrivate(r *Room) bool {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	return r.code != "" && g.invites[r.code] == r
+}
+
+// InviteExists checks if there is the private room waiting for players with given code.
+func InviteExists(code string) bool {
+	g.invitesM.Lock()
+	defer g.invitesM.Unlock()
+	_, ok := g.invites[code]
+	return ok
+}
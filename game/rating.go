@@ -0,0 +1,34 @@
+package game
+
+import (
+	"math"
+
+	"game/models"
+)
+
+const (
+	EloK = 32
+	// EloDisconnectWinnerK is used for the player whose opponent left the game:
+	// the game was not played to the end so he gets less.
+	EloDisconnectWinnerK = 16
+)
+
+// eloExpected returns expected score of the player with given rating against the opponent.
+func eloExpected(rating, opponentRating int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponentRating-rating)/400))
+}
+
+// eloDelta returns rating change of the player with given rating
+// after the game with gameResult against the opponent.
+func eloDelta(k float64, rating, opponentRating, gameResult int) float64 {
+	var actual float64
+	switch gameResult {
+	case models.Win:
+		actual = 1
+	case models.Draw:
+		actual = 0.5
+	case models.Loss:
+		actual = 0
+	}
+	return k * (actual - eloExpected(rating, opponentRating))
+}
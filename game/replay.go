@@ -0,0 +1,174 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/database"
+)
+
+const (
+	MaxReplaySpeed = 10
+)
+
+// Replay is the record of the game: with the seed and inputs the engine
+// reproduces the same states as in the original game.
+//
+//easyjson:json
+type Replay struct {
+	RoomID    string         `json:"roomId"`
+	Seed      int64          `json:"seed"`
+	Players   []uint         `json:"players"` // UIDs by player numbers
+	Version   int            `json:"v"`       // InputVersion of actions in inputs
+	Mode      string         `json:"mode"`
+	Constants *GameRules     `json:"stateConst"`
+	Inputs    []*ReplayInput `json:"inputs"`
+	EndTick   int            `json:"endTick"`
+	Reason    int            `json:"reason"`
+}
+
+//easyjson:json
+type ReplayInput struct {
+	Tick    int     `json:"t"`
+	Player  int     `json:"p"` // player number
+	Seq     int     `json:"s,omitempty"`
+	Actions Actions `json:"a"`
+}
+
+// record appends the action applied at current tick of the engine to the replay.
+func (rp *Replay) record(e *Engine, a *ProcessActions) {
+	rp.Inputs = append(rp.Inputs, &ReplayInput{
+		Tick:    e.tick,
+		Player:  e.Players[a.From],
+		Seq:     a.Seq,
+		Actions: a.Actions,
+	})
+}
+
+// saveReplay saves the replay of finished game in the room to database.
+func (g *Game) saveReplay(r *Room) {
+	r.replay.EndTick = r.engine.tick
+	r.replay.Reason = r.engine.status.Reason
+	data, err := r.replay.MarshalJSON()
+	if err != nil {
+		logger.Errorf("failed to marshal replay of room %v: %v", r.ID, err)
+		return
+	}
+	err = database.SaveReplay(g.dm, r.ID, r.replay.Seed, data)
+	if err != nil {
+		logger.Errorf("failed to save replay of room %v: %v", r.ID, err)
+	}
+}
+
+// LoadReplay returns the replay of the game in the room with given ID.
+func LoadReplay(roomID string) (*Replay, error) {
+	data, err := database.GetReplay(g.dm, roomID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNoReplay
+		}
+		return nil, err
+	}
+	rp := &Replay{}
+	err = rp.UnmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// PlayReplay plays the replay for the connection: runs the engine with recorded
+// seed and inputs and sends states as in the original game.
+// Speed accelerates the game, it should be in [1, MaxReplaySpeed].
+func PlayReplay(conn *websocket.Conn, rp *Replay, speed float64) {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		// messages from client are ignored, wait for disconnection
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	e := newEngine(rp.Constants, rp.Mode, rp.Seed)
+	for i := range rp.Players {
+		e.Players[strconv.Itoa(i+1)] = i + 1
+	}
+	e.state = e.NewInitialState()
+
+	write := func(m *WSMessageToSend) error {
+		t, data, err := encode(conn, m)
+		if err != nil {
+			return err
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+		return conn.WriteMessage(t, data)
+	}
+	err := write(&WSMessageToSend{
+		Status: "replay",
+		Payload: &SpectateInfo{
+			Players:   rp.Players,
+			Mode:      rp.Mode,
+			Constants: rp.Constants,
+		},
+	})
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	next := 0 // next input to apply
+	applyInputs := func() {
+		for ; next < len(rp.Inputs) && rp.Inputs[next].Tick <= e.tick; next++ {
+			e.doAction(&ProcessActions{
+				From:    strconv.Itoa(rp.Inputs[next].Player),
+				Seq:     rp.Inputs[next].Seq,
+				Actions: rp.Inputs[next].Actions,
+			})
+		}
+	}
+	applyInputs()
+
+	ticker := time.NewTicker(time.Duration(float64(rp.Constants.MsPerFrame) / speed))
+	defer ticker.Stop()
+	frames := rp.Constants.snapshotFrames()
+	for {
+		select {
+		case <-ticker.C:
+			ended := e.timeOver() || e.tick >= rp.EndTick
+			if e.tick%frames == 0 || ended {
+				err = write(&WSMessageToSend{
+					Status:  "state",
+					Payload: e.snapshot(),
+				})
+				if err != nil {
+					logger.Error(err)
+					return
+				}
+			}
+			if ended {
+				_ = write(&WSMessageToSend{
+					Status: endReason(rp.Reason),
+				})
+				_ = conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+				_ = conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			e.updateState()
+			applyInputs()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
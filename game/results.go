@@ -0,0 +1,134 @@
+package game
+
+import (
+	"math"
+	"sort"
+
+	"game/models"
+)
+
+// countResults returns results of the finished game for the room players by their game session IDs:
+// places, scores, game results, coins awarded and rating changes.
+// Players who stay till the end are placed by their scores, the ones who left the game
+// are placed after them (who left earlier is placed lower) and lose.
+func (r *Room) countResults(res *Ended) map[string]*models.MatchParticipant {
+	if r.mode == ModeTeam {
+		return r.countTeamResults(res)
+	}
+	remaining, results := r.collectResults()
+
+	sort.Slice(remaining, func(i, j int) bool {
+		ri, rj := results[remaining[i].GameSessionID], results[remaining[j].GameSessionID]
+		if ri.Score != rj.Score {
+			return ri.Score > rj.Score
+		}
+		return ri.PlayerNum < rj.PlayerNum
+	})
+	// draw for everyone if all the players have negative score
+	allNegative := len(remaining) > 1
+	leaders := 0 // count of players on the first place
+	for i, p := range remaining {
+		pRes := results[p.GameSessionID]
+		if i > 0 && pRes.Score == results[remaining[i-1].GameSessionID].Score {
+			pRes.Place = results[remaining[i-1].GameSessionID].Place
+		} else {
+			pRes.Place = i + 1
+		}
+		if pRes.Place == 1 {
+			leaders++
+		}
+		if pRes.Score >= 0 {
+			allNegative = false
+		}
+	}
+	for i := len(r.left) - 1; i >= 0; i-- {
+		results[r.left[i].GameSessionID].Place = len(remaining) + len(r.left) - i
+	}
+
+	total := len(results)
+	for _, p := range remaining {
+		pRes := results[p.GameSessionID]
+		switch {
+		case pRes.Place == 1 && leaders > 1:
+			pRes.GameResult = models.Draw
+			pRes.Coins = int(math.Round(r.rules.DrawCoinsCoefficient * float64(pRes.Score)))
+		case allNegative:
+			pRes.GameResult = models.Draw
+		case pRes.Place == 1:
+			pRes.GameResult = models.Win
+			pRes.Coins = int(math.Round(r.rules.WinnerCoinsCoefficient * float64(pRes.Score)))
+		default:
+			pRes.GameResult = models.Loss
+			// the higher place the more coins
+			pRes.Coins = r.rules.LoserCoinsAmount + int(math.Round(r.rules.WinnerCoinsCoefficient*
+				math.Max(0, float64(pRes.Score))*float64(total-pRes.Place)/float64(total-1)))
+		}
+	}
+	for _, p := range r.left {
+		// left player gets nothing
+		results[p.GameSessionID].GameResult = models.Loss
+	}
+
+	// every pair of players is a separate Elo game
+	players := append(remaining, r.left...)
+	isLeft := func(i int) bool { return i >= len(remaining) }
+	k := float64(EloK) / float64(total-1)
+	kDisconnectWinner := float64(EloDisconnectWinnerK) / float64(total-1)
+	for i, p := range players {
+		pRes := results[p.GameSessionID]
+		delta := 0.0
+		for j, opponent := range players {
+			if i == j {
+				continue
+			}
+			oppRes := results[opponent.GameSessionID]
+			gameResult := models.Draw
+			switch {
+			case allNegative && !isLeft(i) && !isLeft(j):
+			case pRes.Place < oppRes.Place:
+				gameResult = models.Win
+			case pRes.Place > oppRes.Place:
+				gameResult = models.Loss
+			}
+			pairK := k
+			if !isLeft(i) && isLeft(j) {
+				pairK = kDisconnectWinner
+			}
+			delta += eloDelta(pairK, p.Rating, opponent.Rating, gameResult)
+		}
+		pRes.RatingDelta = int(math.Round(delta))
+	}
+
+	return results
+}
+
+// collectResults returns players who stay in the room till the end and results
+// of all the room players by their game session IDs with player numbers and scores filled.
+func (r *Room) collectResults() ([]*Player, map[string]*models.MatchParticipant) {
+	remaining := make([]*Player, 0, len(r.engine.Players))
+	r.Players.Range(func(k, v interface{}) bool {
+		remaining = append(remaining, v.(*Player))
+		return true
+	})
+	results := make(map[string]*models.MatchParticipant, len(r.engine.Players))
+	for _, p := range append(remaining, r.left...) {
+		num := r.engine.Players[p.GameSessionID]
+		results[p.GameSessionID] = &models.MatchParticipant{
+			UID:       p.UserInfo.UID,
+			PlayerNum: num,
+			Score:     r.engine.state.Players[num-1].Score,
+		}
+	}
+	return remaining, results
+}
+
+// endReason returns the reason of the game end for match history.
+func endReason(reason int) string {
+	switch reason {
+	case Disconnected:
+		return models.EndReasonDisconnected
+	case AdminTerminated:
+		return models.EndReasonAdminTerminated
+	}
+	return models.EndReasonTimeOver
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"game/models"
+)
+
+// resultsRoom returns the room of the mode after the game with given scores of players,
+// players with numbers from left have left the game (in order of leaving). Every player
+// has DefaultRating, UID is the player number.
+func resultsRoom(mode string, scores []int, left ...int) *Room {
+	r := NewRoom(DefaultGameRules(), mode)
+	r.engine = newEngine(r.rules, mode, 1)
+	r.engine.state = &State{}
+	if mode == ModeTeam {
+		r.engine.state.Teams = make([]*TeamData, TeamsCount)
+		for t := range r.engine.state.Teams {
+			r.engine.state.Teams[t] = &TeamData{}
+		}
+	}
+	players := make([]*Player, len(scores))
+	for i, score := range scores {
+		players[i] = &Player{
+			UserInfo:      &User{UID: uint(i + 1)},
+			GameSessionID: fmt.Sprintf("player%v", i+1),
+			Rating:        DefaultRating,
+		}
+		r.engine.Players[players[i].GameSessionID] = i + 1
+		r.engine.state.Players = append(r.engine.state.Players, &PlayerData{Score: score})
+		r.Players.Store(players[i].GameSessionID, players[i])
+		if mode == ModeTeam {
+			r.engine.state.Teams[teamOf(i+1)-1].Score += score
+		}
+	}
+	for _, num := range left {
+		r.Players.Delete(players[num-1].GameSessionID)
+		r.left = append(r.left, players[num-1])
+	}
+	return r
+}
+
+// participant is the expected result of the player.
+type participant struct {
+	place      int
+	gameResult int
+	coins      int
+	rating     int // sign of rating delta
+}
+
+func checkResults(t *testing.T, results map[string]*models.MatchParticipant, want []participant) {
+	t.Helper()
+	if len(results) != len(want) {
+		t.Fatalf("got %v results, want %v", len(results), len(want))
+	}
+	for i, w := range want {
+		res := results[fmt.Sprintf("player%v", i+1)]
+		if res == nil {
+			t.Fatalf("no result of player %v", i+1)
+		}
+		if res.Place != w.place || res.GameResult != w.gameResult || res.Coins != w.coins {
+			t.Errorf("player %v: got place %v, result %v, coins %v, want %v, %v, %v",
+				i+1, res.Place, res.GameResult, res.Coins, w.place, w.gameResult, w.coins)
+		}
+		if sign(res.RatingDelta) != w.rating {
+			t.Errorf("player %v: got rating delta %v, want sign %v", i+1, res.RatingDelta, w.rating)
+		}
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func coins(coef float64, score float64) int {
+	return int(math.Round(coef * score))
+}
+
+func TestCountResults(t *testing.T) {
+	rules := DefaultGameRules()
+	tests := []struct {
+		name   string
+		scores []int
+		left   []int
+		reason int
+		want   []participant
+	}{
+		{
+			name:   "winner and loser",
+			scores: []int{10, 5},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 10), 1},
+				{2, models.Loss, rules.LoserCoinsAmount, -1},
+			},
+		},
+		{
+			name:   "draw",
+			scores: []int{5, 5},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 5), 0},
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 5), 0},
+			},
+		},
+		{
+			name:   "all negative",
+			scores: []int{-1, -3},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Draw, 0, 0},
+				{2, models.Draw, 0, 0},
+			},
+		},
+		{
+			name:   "left player loses with the best score",
+			scores: []int{3, 10},
+			left:   []int{2},
+			reason: Disconnected,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 3), 1},
+				{2, models.Loss, 0, -1},
+			},
+		},
+		{
+			name:   "who left earlier is placed lower",
+			scores: []int{1, 2, 3},
+			left:   []int{3, 2},
+			reason: Disconnected,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 1), 1},
+				{2, models.Loss, 0, 0}, // lost to the winner, won the one who left earlier
+				{3, models.Loss, 0, -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resultsRoom(ModeSolo, tt.scores, tt.left...)
+			checkResults(t, r.countResults(&Ended{Reason: tt.reason}), tt.want)
+		})
+	}
+}
@@ -3,16 +3,25 @@ package game
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
+	"game/models"
 )
 
 const (
-	MaxPlayers = 2
+	MinPlayers = 2
+	MaxPlayers = 6
+
+	ReconnectWindow = 10 * time.Second // time for disconnected player to come back
+
+	MaxCatchUpFrames = 5 // engine updates per tick when the room is late
 )
 
 type Room struct {
@@ -21,12 +30,39 @@ type Room struct {
 	Total   int
 	TotalM  *sync.Mutex
 
+	Spectators   *sync.Map
+	AddSpectator chan *Spectator
+
 	Ctx    context.Context
 	cancel func()
 
+	rules *GameRules
+	mode  string
+
+	code        string      // invite code of private room
+	inviteTimer *time.Timer // closes private room nobody joined
+
 	Unregister chan *Player
+	Reconnect  chan *Player
+	stop       chan struct{} // finishes the game before time is over
+	started    chan struct{} // closed when the engine is created
+	expired    chan *Player  // players whose reconnect window ran out
+	kicks      chan *Player  // players with too many anti-cheat violations
+	adminKicks chan *Player
+	terminate  chan struct{}          // finishes the game with AdminTerminated
+	inspect    chan chan *RoomDetails // admin requests for the room details
 
-	engine *Engine
+	startedAt  time.Time
+	endedAt    time.Time
+	playerUIDs []uint    // by player numbers
+	left       []*Player // players whose reconnect window ran out, in order of leaving
+	engine     *Engine
+	snapshots  *time.Ticker         // sends states to clients
+	version    int                  // version of the last state sent
+	history    [StateHistory]*State // last states by versions, bases for deltas
+	replay     *Replay
+	results    map[string]*models.MatchParticipant // by GameSessionID
+	casual     bool                                // results don't change stats, coins and rating
 }
 
 //easyjson:json
@@ -39,18 +75,29 @@ const (
 	Success = iota
 	TimeOver
 	Disconnected
+	AdminTerminated
 )
 
 //easyjson:json
 type StartInfo struct {
-	OpponentID uint   `json:"opponentId"`
-	PlayerNum  uint   `json:"playerNum"`
-	Constants  *Const `json:"stateConst"`
+	Players   []uint        `json:"players"` // UIDs by player numbers
+	PlayerNum uint          `json:"playerNum"`
+	Mode      string        `json:"mode"`
+	Constants *GameRules    `json:"stateConst"`
+	Elapsed   time.Duration `json:"elapsed,omitempty"`   // for reconnected player
+	LastInput int           `json:"lastInput,omitempty"` // for reconnected player to continue numbering inputs
 }
 
 //easyjson:json
-type Const struct {
-	GameTime time.Duration `json:"gameTime"`
+type OpponentInfo struct {
+	PlayerNum uint `json:"playerNum"`
+}
+
+//easyjson:json
+type GameOverInfo struct {
+	RatingDelta int   `json:"ratingDelta"`
+	Rating      int   `json:"rating"`
+	Seed        int64 `json:"seed"`
 }
 
 type Ended struct {
@@ -60,89 +107,292 @@ type Ended struct {
 
 // Run runs the game in the room.
 func (r *Room) Run() {
-	logger.Infof("game started in room %v", r.ID)
-	var player1, player2 *Player
-	i := 1
+	players := make([]*Player, 0, r.rules.PlayersCount)
 	r.Players.Range(func(k, v interface{}) bool {
-		player := v.(*Player)
-		if i == 1 {
-			player1 = player
-		} else {
-			player2 = player
-		}
-		i++
-		go player.Listen()
+		players = append(players, v.(*Player))
 		return true
 	})
+	if r.mode == ModeTeam {
+		players = balanceTeams(players)
+	}
+	seed := time.Now().UnixNano()
 	var err error
-	r.engine, err = NewEngine(r, player1, player2)
+	r.engine, err = NewEngine(r, r.rules, r.mode, players, seed)
 	if err != nil {
 		logger.Errorf("engine cannot be created: %v", err)
 		return
 	}
 
-	player1.SendMessage <- &WSMessageToSend{
-		Status: "started",
-		Payload: &StartInfo{
-			OpponentID: player2.UserInfo.UID,
-			PlayerNum:  1,
-			Constants: &Const{
-				GameTime: GameTime,
-			},
-		},
+	r.playerUIDs = make([]uint, 0, len(players))
+	for _, player := range players {
+		r.playerUIDs = append(r.playerUIDs, player.UserInfo.UID)
 	}
-	player2.SendMessage <- &WSMessageToSend{
-		Status: "started",
-		Payload: &StartInfo{
-			OpponentID: player1.UserInfo.UID,
-			PlayerNum:  2,
-			Constants: &Const{
-				GameTime: GameTime,
+	close(r.started)
+	for i, player := range players {
+		r.send(player, &WSMessageToSend{
+			Status: "started",
+			Payload: &StartInfo{
+				Players:   r.playerUIDs,
+				PlayerNum: uint(i + 1),
+				Mode:      r.mode,
+				Constants: r.rules,
 			},
-		},
+		})
 	}
 
-	// run game engine
-	r.engine.ticker = time.NewTicker(MsPerFrame)
-	r.engine.randomizer = time.NewTicker(TargetRandomsEvery)
-	r.engine.timer = time.NewTimer(GameTime)
+	r.replay = &Replay{
+		RoomID:    r.ID,
+		Seed:      seed,
+		Players:   r.playerUIDs,
+		Version:   InputVersion,
+		Mode:      r.mode,
+		Constants: r.rules,
+	}
+
+	// run game engine: simulation runs with fixed timestep of MsPerFrame,
+	// states are sent to clients every SnapshotEvery
+	logger.Infof("game started in room %v with seed %v", r.ID, seed)
+	r.startedAt = time.Now()
+	r.pushState(r.engine.snapshot())
+	r.engine.ticker = time.NewTicker(r.rules.MsPerFrame)
+	r.snapshots = time.NewTicker(r.rules.SnapshotEvery)
 	for {
 		select {
 		case <-r.engine.ticker.C:
 			logger.Debugf("room %v tick", r.ID)
-			r.broadcast(&WSMessageToSend{
-				Status:  "state",
-				Payload: r.engine.state.copyState(),
-			})
-			r.engine.updateState()
-		case <-r.engine.randomizer.C:
-			logger.Debug("new product incoming")
-			r.engine.randomTarget()
-		case <-r.engine.timer.C:
-			logger.Info("time over in game engine")
+			tickStart := time.Now()
+			// the engine catches up with the clock if the room was late
+			due := r.engine.dueTicks(tickStart.Sub(r.startedAt))
+			for i := 0; i < MaxCatchUpFrames && r.engine.tick < due; i++ {
+				if r.engine.timeOver() {
+					logger.Info("time over in game engine")
+					r.pushState(r.engine.snapshot())
+					r.finish(&Ended{
+						Reason: TimeOver,
+					})
+					logger.Info("end of game engine")
+					return
+				}
+				r.engine.updateState()
+			}
+			metrics.ObserveTick(time.Since(tickStart))
+		case <-r.snapshots.C:
+			r.pushState(r.engine.snapshot())
+		case a := <-r.engine.Update:
+			r.replay.record(r.engine, a)
+			_ = r.engine.doAction(a)
+		case p := <-r.kicks:
+			if r.removeCheater(p) {
+				return
+			}
+		case p := <-r.adminKicks:
+			if r.removePlayer(p, KickAdmin) {
+				return
+			}
+		case <-r.terminate:
 			r.finish(&Ended{
-				Reason: TimeOver,
+				Reason: AdminTerminated,
 			})
-			logger.Info("end of game engine")
 			return
-		case a := <-r.engine.Update:
-			r.engine.doAction(a)
+		case reply := <-r.inspect:
+			reply <- r.details(true)
 		case p := <-r.Unregister:
-			logger.Infof("player disconnected signal in room %v", r.ID)
+			if r.isCurrent(p) && !p.disconnected {
+				logger.Infof("player disconnected signal in room %v", r.ID)
+				r.disconnect(p)
+			}
+		case <-r.stop:
+			logger.Infof("room %v: game is stopped", r.ID)
 			r.finish(&Ended{
-				Reason: Disconnected,
-				Info:   p,
+				Reason: TimeOver,
 			})
 			return
+		case p := <-r.Reconnect:
+			r.reconnect(p)
+		case s := <-r.AddSpectator:
+			r.addSpectator(s)
+		case p := <-r.expired:
+			if r.isCurrent(p) && p.disconnected {
+				logger.Infof("room %v: reconnect window of player %v ran out", r.ID, p.GameSessionID)
+				r.leave(p)
+				if !r.enoughPlayers() {
+					r.finish(&Ended{
+						Reason: Disconnected,
+						Info:   p,
+					})
+					return
+				}
+			}
 		}
 	}
 }
 
-// broadcast sends the message WSMessageToSend to all players in the room.
-func (r *Room) broadcast(m *WSMessageToSend) {
+// Stop finishes the game in the room as if time is over.
+func (r *Room) Stop() {
+	select {
+	case r.stop <- struct{}{}:
+	default: // already stopping
+	}
+}
+
+// isCurrent checks if p is the actual object of player in the room
+// (not replaced with reconnected one).
+func (r *Room) isCurrent(p *Player) bool {
+	v, ok := r.Players.Load(p.GameSessionID)
+	return ok && v.(*Player) == p
+}
+
+// disconnect drops connection of the player and gives him ReconnectWindow to come back.
+func (r *Room) disconnect(p *Player) {
+	if p.isBot() { // bot has no connection and can't reconnect
+		return
+	}
+	p.disconnected = true
+	p.dropConn()
+	p.reconnectTimer = time.AfterFunc(ReconnectWindow, func() {
+		select {
+		case r.expired <- p:
+		case <-r.Ctx.Done():
+		}
+	})
+	r.broadcast(&WSMessageToSend{
+		Status: "opponent_reconnecting",
+		Payload: &OpponentInfo{
+			PlayerNum: uint(r.engine.Players[p.GameSessionID]),
+		},
+	})
+}
+
+// leave removes the player from the room, he loses the game. The game goes on
+// if there are enough players.
+func (r *Room) leave(p *Player) {
+	r.Players.Delete(p.GameSessionID)
+	r.left = append(r.left, p)
+	logger.Infof("room %v: player %v left the game (game session %v)", r.ID, p.UserInfo.UID, p.GameSessionID)
+	r.broadcast(&WSMessageToSend{
+		Status: "opponent_left",
+		Payload: &OpponentInfo{
+			PlayerNum: uint(r.engine.Players[p.GameSessionID]),
+		},
+	})
+}
+
+// isStarted checks if the game in the room has started.
+func (r *Room) isStarted() bool {
+	select {
+	case <-r.started:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasBots checks if some players of the room are controlled by server.
+func (r *Room) hasBots() bool {
+	bots := false
+	r.Players.Range(func(k, v interface{}) bool {
+		bots = v.(*Player).isBot()
+		return !bots
+	})
+	return bots
+}
+
+// enoughPlayers checks if the game can go on after players left: there should be
+// at least MinPlayers and in team mode every team should have a player.
+func (r *Room) enoughPlayers() bool {
+	if len(r.engine.Players)-len(r.left) < MinPlayers {
+		return false
+	}
+	return r.mode != ModeTeam || r.teamsAlive()
+}
+
+// reconnect replaces the disconnected player with the same GameSessionID with p (new connection).
+// The player gets the current game info and continues the game. The player who is still
+// connected keeps his slot, the new connection is rejected.
+func (r *Room) reconnect(p *Player) {
+	v, ok := r.Players.Load(p.GameSessionID)
+	if !ok {
+		p.dropConn()
+		return
+	}
+	old := v.(*Player)
+	if !old.disconnected {
+		logger.Infof("player with id %v is already playing in room %v", p.UserInfo.UID, r.ID)
+		go rejectUser(p.UserInfo, "playing")
+		return
+	}
+	old.reconnectTimer.Stop()
+	p.setRoom(r)
+	p.Rating = old.Rating
+	atomic.StoreInt32(&p.violations, atomic.LoadInt32(&old.violations))
+	r.Players.Store(p.GameSessionID, p)
+	logger.Infof("room %v: player %v reconnected (game session %v)", r.ID, p.UserInfo.UID, p.GameSessionID)
+
+	p.startSend()
+	go p.Listen()
+	go p.heartbeat()
+	playerNum := uint(r.engine.Players[p.GameSessionID])
+	r.send(p, &WSMessageToSend{
+		Status: "reconnected",
+		Payload: &StartInfo{
+			Players:   r.playerUIDs,
+			PlayerNum: playerNum,
+			Mode:      r.mode,
+			Constants: r.rules,
+			Elapsed:   r.engine.elapsed(),
+			LastInput: r.engine.state.Players[playerNum-1].LastInput,
+		},
+	})
 	r.Players.Range(func(k, v interface{}) bool {
 		player := v.(*Player)
-		player.SendMessage <- m
+		if player != p {
+			r.send(player, &WSMessageToSend{
+				Status: "opponent_back",
+				Payload: &OpponentInfo{
+					PlayerNum: playerNum,
+				},
+			})
+		}
+		return true
+	})
+}
+
+// addSpectator attaches the spectator to the room and sends him the game info.
+func (r *Room) addSpectator(s *Spectator) {
+	s.Room = r
+	r.Spectators.Store(s.ID, s)
+	metrics.AddSpectatorToCounter()
+	go s.Send()
+	go s.Listen()
+	s.SendMessage <- &WSMessageToSend{
+		Status: "spectating",
+		Payload: &SpectateInfo{
+			Players:   r.playerUIDs,
+			Mode:      r.mode,
+			Constants: r.rules,
+			Elapsed:   r.engine.elapsed(),
+		},
+	}
+	logger.Infof("spectator %v joined room %v", s.ID, r.ID)
+}
+
+// broadcastSpectators sends the message WSMessageToSend to all spectators of the room.
+// Spectators with full send buffer miss the message, so they can't slow down the game.
+func (r *Room) broadcastSpectators(m *WSMessageToSend) {
+	r.Spectators.Range(func(k, v interface{}) bool {
+		s := v.(*Spectator)
+		select {
+		case s.SendMessage <- m:
+		default:
+		}
+		return true
+	})
+}
+
+// broadcast sends the message WSMessageToSend to all connected players in the room.
+func (r *Room) broadcast(m *WSMessageToSend) {
+	r.Players.Range(func(k, v interface{}) bool {
+		r.send(v.(*Player), m)
 		return true
 	})
 }
@@ -150,30 +400,67 @@ func (r *Room) broadcast(m *WSMessageToSend) {
 // finish finishes the game in the room.
 func (r *Room) finish(res *Ended) {
 	r.engine.ticker.Stop()
-	r.engine.randomizer.Stop()
-	r.engine.timer.Stop()
+	r.snapshots.Stop()
+	r.endedAt = time.Now()
+	r.results = r.countResults(res)
+	// games with bots give no rewards, otherwise coins could be farmed,
+	// games stopped by admin are not played to the end
+	r.casual = r.hasBots() || res.Reason == AdminTerminated
+	if r.casual {
+		for _, pRes := range r.results {
+			pRes.RatingDelta = 0
+			pRes.Coins = 0
+		}
+	}
+	r.flagCheaters(r.results)
+	metrics.AddFinishedMatch(endReason(res.Reason))
+	var status string
 	switch res.Reason {
 	case TimeOver:
 		logger.Infof("room %v: game over with time over", r.ID)
-		r.broadcast(&WSMessageToSend{
-			Status: "time_over",
-		})
+		status = "time_over"
 	case Disconnected:
 		left := res.Info.(*Player)
-		r.Players.Delete(left.GameSessionID)
 		logger.Infof("room %v: game over with disconnection of player %v (game session %v)",
 			r.ID, left.UserInfo.UID, left.GameSessionID)
-		r.broadcast(&WSMessageToSend{
-			Status: "disconnected",
-		})
+		status = "disconnected"
+	case AdminTerminated:
+		logger.Infof("room %v: game is terminated by admin", r.ID)
+		status = "admin_terminated"
 	}
+	r.Players.Range(func(k, v interface{}) bool {
+		player := v.(*Player)
+		if player.disconnected {
+			return true
+		}
+		info := &GameOverInfo{
+			Rating: player.Rating,
+			Seed:   r.engine.Seed,
+		}
+		if res, ok := r.results[player.GameSessionID]; ok {
+			info.RatingDelta = res.RatingDelta
+			info.Rating += res.RatingDelta
+		}
+		r.send(player, &WSMessageToSend{
+			Status:  status,
+			Payload: info,
+		})
+		return true
+	})
+	r.broadcastSpectators(&WSMessageToSend{
+		Status: status,
+	})
 	r.engine.status = res
+	g.saveReplay(r)
 
 	time.Sleep(1 * time.Second)
 	r.cancel()
 
 	r.Players.Range(func(k, v interface{}) bool {
 		player := v.(*Player)
+		if player.disconnected || player.isBot() {
+			return true
+		}
 		// graceful disconnect
 		_ = player.UserInfo.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
 		_ = player.UserInfo.Conn.WriteMessage(websocket.CloseMessage,
@@ -188,15 +475,27 @@ func (r *Room) finish(res *Ended) {
 	g.CloseRoom <- r
 }
 
-// NewRoom initializes new object of Room.
-func NewRoom() *Room {
+// NewRoom initializes new object of Room with given game rules and mode.
+func NewRoom(rules *GameRules, mode string) *Room {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Room{
-		ID:         uuid.NewV4().String(),
-		Players:    &sync.Map{},
-		TotalM:     &sync.Mutex{},
-		Ctx:        ctx,
-		cancel:     cancel,
-		Unregister: make(chan *Player, 1),
+		ID:           uuid.NewV4().String(),
+		Players:      &sync.Map{},
+		TotalM:       &sync.Mutex{},
+		Spectators:   &sync.Map{},
+		AddSpectator: make(chan *Spectator),
+		Ctx:          ctx,
+		cancel:       cancel,
+		rules:        rules,
+		mode:         mode,
+		Unregister:   make(chan *Player, 1),
+		Reconnect:    make(chan *Player),
+		stop:         make(chan struct{}, 1),
+		started:      make(chan struct{}),
+		expired:      make(chan *Player, 1),
+		kicks:        make(chan *Player, 1),
+		adminKicks:   make(chan *Player, 1),
+		terminate:    make(chan struct{}, 1),
+		inspect:      make(chan chan *RoomDetails),
 	}
 }
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"time"
+)
+
+const (
+	MaxMsPerFrame = 100 * time.Millisecond // slower engine is not playable, anticheat needs several frames per second
+)
+
+// GameRules are the balance settings of the game. They are sent to clients in StartInfo.
+//
+//easyjson:json
+type GameRules struct {
+	PlayersCount       int           `json:"playersCount"`
+	GameTime           time.Duration `json:"gameTime"`
+	MsPerFrame         time.Duration `json:"msPerFrame"`
+	SnapshotEvery      time.Duration `json:"snapshotEvery"` // period of sending states, not less than msPerFrame
+	TargetCount        int           `json:"targetCount"`
+	TargetVariaty      int           `json:"targetVariaty"`
+	TargetRandomsEvery time.Duration `json:"targetRandomsEvery"`
+
+	PlayerSpeed         float64 `json:"playerSpeed"`
+	PlayerJumpSpeed     float64 `json:"playerJumpSpeed"`
+	PlayerGravity       float64 `json:"playerGravity"`
+	PlayerSuccessPoints int     `json:"playerSuccessPoints"`
+	PlayerFailurePoints int     `json:"playerFailurePoints"`
+
+	ProductSpeed float64 `json:"productSpeed"`
+
+	WinnerCoinsCoefficient float64 `json:"winnerCoinsCoefficient"`
+	LoserCoinsAmount       int     `json:"loserCoinsAmount"`
+	DrawCoinsCoefficient   float64 `json:"drawCoinsCoefficient"`
+}
+
+// DefaultGameRules returns the rules with default values of constants.
+func DefaultGameRules() *GameRules {
+	return &GameRules{
+		PlayersCount:       PlayersCount,
+		GameTime:           GameTime,
+		MsPerFrame:         MsPerFrame,
+		SnapshotEvery:      SnapshotEvery,
+		TargetCount:        TargetCount,
+		TargetVariaty:      TargetVariaty,
+		TargetRandomsEvery: TargetRandomsEvery,
+
+		PlayerSpeed:         PlayerSpeed,
+		PlayerJumpSpeed:     PlayerJumpSpeed,
+		PlayerGravity:       PlayerGravity,
+		PlayerSuccessPoints: PlayerSuccessPoints,
+		PlayerFailurePoints: PlayerFailurePoints,
+
+		ProductSpeed: ProductSpeed,
+
+		WinnerCoinsCoefficient: WinnerCoinsCoefficient,
+		LoserCoinsAmount:       LoserCoinsAmount,
+		DrawCoinsCoefficient:   DrawCoinsCoefficient,
+	}
+}
+
+// LoadGameRules reads the rules from JSON file. Missing values are set to defaults.
+func LoadGameRules(path string) (*GameRules, error) {
+	j, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules := DefaultGameRules()
+	err = rules.UnmarshalJSON(j)
+	if err != nil {
+		return nil, err
+	}
+	err = rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Validate checks if the rules can be used by the engine.
+func (rules *GameRules) Validate() error {
+	switch {
+	case rules.PlayersCount < MinPlayers || rules.PlayersCount > MaxPlayers:
+		return fmt.Errorf("playersCount should be in [%v, %v]", MinPlayers, MaxPlayers)
+	case rules.MsPerFrame <= 0 || rules.MsPerFrame > MaxMsPerFrame:
+		return fmt.Errorf("msPerFrame should be in (0, %v]", MaxMsPerFrame)
+	case rules.SnapshotEvery < rules.MsPerFrame:
+		return fmt.Errorf("snapshotEvery should be not less than msPerFrame")
+	case rules.GameTime < rules.MsPerFrame:
+		return fmt.Errorf("gameTime should be not less than msPerFrame")
+	case rules.TargetRandomsEvery < rules.MsPerFrame:
+		return fmt.Errorf("targetRandomsEvery should be not less than msPerFrame")
+	case rules.TargetCount <= 0 || rules.TargetVariaty <= 0:
+		return fmt.Errorf("targetCount and targetVariaty should be positive")
+	case rules.ProductSpeed <= 0:
+		return fmt.Errorf("productSpeed should be positive")
+	case rules.PlayerSpeed <= 0:
+		return fmt.Errorf("playerSpeed should be positive")
+	case rules.PlayerJumpSpeed < 0:
+		return fmt.Errorf("playerJumpSpeed should be not negative")
+	case rules.PlayerGravity <= 0:
+		return fmt.Errorf("playerGravity should be positive")
+	}
+	return nil
+}
+
+// snapshotFrames returns count of engine frames between states sent.
+func (rules *GameRules) snapshotFrames() int {
+	frames := int(math.Round(float64(rules.SnapshotEvery) / float64(rules.MsPerFrame)))
+	if frames < 1 {
+		return 1
+	}
+	return frames
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGameRulesValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(r *GameRules)
+		valid bool
+	}{
+		{
+			name:  "defaults",
+			edit:  func(r *GameRules) {},
+			valid: true,
+		},
+		{
+			name:  "no jumps",
+			edit:  func(r *GameRules) { r.PlayerJumpSpeed = 0 },
+			valid: true,
+		},
+		{
+			name: "slowest engine",
+			edit: func(r *GameRules) {
+				r.MsPerFrame = MaxMsPerFrame
+				r.SnapshotEvery = r.MsPerFrame
+			},
+			valid: true,
+		},
+		{
+			name: "too slow engine",
+			edit: func(r *GameRules) {
+				r.MsPerFrame = 2 * time.Second
+				r.SnapshotEvery = r.MsPerFrame
+				r.TargetRandomsEvery = r.MsPerFrame
+			},
+		},
+		{
+			name: "no gravity",
+			edit: func(r *GameRules) { r.PlayerGravity = 0 },
+		},
+		{
+			name: "negative gravity",
+			edit: func(r *GameRules) { r.PlayerGravity = -0.4 },
+		},
+		{
+			name: "standing player",
+			edit: func(r *GameRules) { r.PlayerSpeed = 0 },
+		},
+		{
+			name: "negative jump speed",
+			edit: func(r *GameRules) { r.PlayerJumpSpeed = -1 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultGameRules()
+			tt.edit(rules)
+			err := rules.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("invalid rules passed validation")
+			}
+		})
+	}
+}
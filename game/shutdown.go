@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+)
+
+const (
+	ShutdownCheckEvery = 100 * time.Millisecond
+	// StopRoomsTimeout is time for forcibly stopped rooms to finish and save results.
+	StopRoomsTimeout = 10 * time.Second
+)
+
+// Draining checks if the server is shutting down and doesn't accept new players.
+func (g *Game) Draining() bool {
+	return atomic.LoadInt32(&g.draining) == 1
+}
+
+// Shutdown stops accepting new players, tells the waiting ones (in the queue and
+// private rooms) to reconnect elsewhere
+// and waits for the running games to end. Games still running when ctx is done are
+// stopped and finished with current scores. Shutdown returns when all the rooms are closed
+// and their results are saved.
+func (g *Game) Shutdown(ctx context.Context) {
+	atomic.StoreInt32(&g.draining, 1)
+	logger.Infof("shutdown: %v waiting players and %v private rooms are sent away, waiting for %v rooms",
+		g.sendAwayWaiting(), g.sendAwayPrivate(), g.total())
+	// players who were queued while draining started
+	defer g.sendAwayPrivate()
+	defer g.sendAwayWaiting()
+
+	if g.waitRooms(ctx) {
+		return
+	}
+	logger.Infof("shutdown: deadline exceeded, stopping %v rooms", g.total())
+	g.Rooms.Range(func(k, v interface{}) bool {
+		v.(*Room).Stop()
+		return true
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), StopRoomsTimeout)
+	defer cancel()
+	if !g.waitRooms(ctx) {
+		logger.Errorf("shutdown: %v rooms are not closed", g.total())
+	}
+}
+
+// sendAwayWaiting tells the players waiting in the queue to reconnect
+// elsewhere and returns their count.
+func (g *Game) sendAwayWaiting() int {
+	tickets := g.Matchmaker.Drain()
+	for _, t := range tickets {
+		t.Player.sendAway("server_shutdown")
+	}
+	return len(tickets)
+}
+
+// waitRooms waits until all the rooms are closed or ctx is done.
+// Returns true if all rooms are closed.
+func (g *Game) waitRooms(ctx context.Context) bool {
+	ticker := time.NewTicker(ShutdownCheckEvery)
+	defer ticker.Stop()
+	for {
+		if g.total() == 0 {
+			return true
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (g *Game) total() int {
+	g.TotalM.Lock()
+	defer g.TotalM.Unlock()
+	return g.Total
+}
+
+// Shutdown gracefully shuts down the Game.
+func Shutdown(ctx context.Context) {
+	g.Shutdown(ctx)
+}
+
+// Draining checks if the Game is shutting down.
+func Draining() bool {
+	return g.Draining()
+}
+
+// IsPlaying checks if user with given uID plays in some room now.
+func IsPlaying(uID uint) bool {
+	r, _ := g.findPlayerRoom(uID)
+	return r != nil
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/go-park-mail-ru/2018_2_DeadMolesStudio/logger"
+
+	"game/metrics"
+)
+
+// Spectator is a read-only connection which watches the game in the room.
+type Spectator struct {
+	ID   string
+	Conn *websocket.Conn
+	Room *Room
+
+	Ctx    context.Context
+	cancel func()
+
+	SendMessage chan *WSMessageToSend
+}
+
+//easyjson:json
+type SpectateInfo struct {
+	Players   []uint        `json:"players"` // UIDs by player numbers
+	Mode      string        `json:"mode"`
+	Constants *GameRules    `json:"stateConst"`
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+// Listen reads messages from spectator only to detect disconnection, all of them are ignored.
+func (s *Spectator) Listen() {
+	for {
+		_, _, err := s.Conn.ReadMessage()
+		if err != nil {
+			s.Room.Spectators.Delete(s.ID)
+			metrics.SubtractSpectatorFromCounter()
+			s.cancel()
+			s.Conn.Close()
+			logger.Infof("spectator %v left room %v", s.ID, s.Room.ID)
+			return
+		}
+	}
+}
+
+// Send writes every message from SendMessage channel to spectator and closes
+// the connection when game in room ends.
+func (s *Spectator) Send() {
+	for {
+		select {
+		case m := <-s.SendMessage:
+			s.write(m)
+		case <-s.Room.Ctx.Done():
+			// flush final messages
+			for len(s.SendMessage) > 0 {
+				s.write(<-s.SendMessage)
+			}
+			_ = s.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+			_ = s.Conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			s.Conn.Close()
+			return
+		case <-s.Ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Spectator) write(m *WSMessageToSend) {
+	t, data, err := encode(s.Conn, m)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	_ = s.Conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
+	err = s.Conn.WriteMessage(t, data)
+	if err != nil {
+		// Listen will notice that connection is broken
+		s.Conn.Close()
+	}
+}
+
+// NewSpectator initializes new object of Spectator with given connection.
+func NewSpectator(conn *websocket.Conn) *Spectator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Spectator{
+		ID:          uuid.NewV4().String(),
+		Conn:        conn,
+		Ctx:         ctx,
+		cancel:      cancel,
+		SendMessage: make(chan *WSMessageToSend, 100),
+	}
+}
@@ -0,0 +1,183 @@
+package game
+
+import (
+	"math"
+	"sort"
+
+	"game/models"
+)
+
+// game modes, chosen by player on connection
+const (
+	ModeSolo = "solo" // free-for-all
+	ModeTeam = "team" // 2 vs 2
+
+	TeamSize   = 2
+	TeamsCount = 2
+)
+
+//easyjson:json
+type TeamData struct {
+	Score      int   `json:"score"`      // sum of teammates' scores
+	TargetList []int `json:"targetList"` // shared by teammates, 1-6
+}
+
+// ParseMode returns the game mode by its name, empty name is ModeSolo.
+func ParseMode(mode string) (string, error) {
+	switch mode {
+	case "", ModeSolo:
+		return ModeSolo, nil
+	case ModeTeam:
+		return ModeTeam, nil
+	}
+	return "", ErrUnknownMode
+}
+
+// roomSize returns count of players in the room of the mode.
+func (g *Game) roomSize(mode string) int {
+	if mode == ModeTeam {
+		return TeamSize * TeamsCount
+	}
+	return g.rules.PlayersCount
+}
+
+// teamOf returns the team number (1-based) of the player number in team mode.
+func teamOf(playerNum int) int {
+	return (playerNum-1)%TeamsCount + 1
+}
+
+// balanceTeams orders players so that teams given by teamOf have close ratings:
+// the strongest players are distributed among teams in snake order.
+func balanceTeams(players []*Player) []*Player {
+	sorted := make([]*Player, len(players))
+	copy(sorted, players)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Rating > sorted[j].Rating
+	})
+	members := make([][]*Player, TeamsCount)
+	for i, p := range sorted {
+		round, t := i/TeamsCount, i%TeamsCount
+		if round%2 == 1 {
+			t = TeamsCount - 1 - t
+		}
+		members[t] = append(members[t], p)
+	}
+	balanced := make([]*Player, 0, len(players))
+	for i := 0; len(balanced) < len(players); i++ {
+		for t := range members {
+			if i < len(members[t]) {
+				balanced = append(balanced, members[t][i])
+			}
+		}
+	}
+	return balanced
+}
+
+// teamsAlive checks if every team has at least one player who has not left the game.
+func (r *Room) teamsAlive() bool {
+	alive := make([]bool, TeamsCount)
+	r.Players.Range(func(k, v interface{}) bool {
+		alive[teamOf(r.engine.Players[v.(*Player).GameSessionID])-1] = true
+		return true
+	})
+	for _, a := range alive {
+		if !a {
+			return false
+		}
+	}
+	return true
+}
+
+// countTeamResults returns results of the finished team game for the room players by their
+// game session IDs. Every member gets the result of his team: the team with more points wins,
+// the team whose players all left loses. Players who left lose regardless of their team.
+func (r *Room) countTeamResults(res *Ended) map[string]*models.MatchParticipant {
+	remaining, results := r.collectResults()
+	for _, pRes := range results {
+		pRes.Team = teamOf(pRes.PlayerNum)
+	}
+
+	alive := make([]bool, TeamsCount)
+	members := make([]int, TeamsCount)
+	ratingSum := make([]int, TeamsCount)
+	for _, p := range remaining {
+		alive[results[p.GameSessionID].Team-1] = true
+	}
+	for _, p := range append(remaining, r.left...) {
+		t := results[p.GameSessionID].Team - 1
+		members[t]++
+		ratingSum[t] += p.Rating
+	}
+
+	// place of every team: teams with the same score share the place
+	teams := r.engine.state.Teams
+	places := make([]int, TeamsCount)
+	allNegative := true
+	for t := range teams {
+		places[t] = 1
+		for o := range teams {
+			if o == t {
+				continue
+			}
+			if alive[o] && !alive[t] || alive[o] == alive[t] && teams[o].Score > teams[t].Score {
+				places[t]++
+			}
+		}
+		if teams[t].Score >= 0 || !alive[t] {
+			allNegative = false
+		}
+	}
+	leaders := 0
+	for _, place := range places {
+		if place == 1 {
+			leaders++
+		}
+	}
+
+	for _, p := range remaining {
+		pRes := results[p.GameSessionID]
+		t := pRes.Team - 1
+		pRes.Place = places[t]
+		// team reward is shared by its members
+		share := float64(teams[t].Score) / float64(members[t])
+		switch {
+		case allNegative:
+			pRes.GameResult = models.Draw
+		case pRes.Place == 1 && leaders > 1:
+			pRes.GameResult = models.Draw
+			pRes.Coins = int(math.Round(r.rules.DrawCoinsCoefficient * share))
+		case pRes.Place == 1:
+			pRes.GameResult = models.Win
+			pRes.Coins = int(math.Round(r.rules.WinnerCoinsCoefficient * share))
+		default:
+			pRes.GameResult = models.Loss
+			pRes.Coins = r.rules.LoserCoinsAmount
+		}
+	}
+	for _, p := range r.left {
+		// left player gets nothing
+		pRes := results[p.GameSessionID]
+		pRes.Place = TeamsCount + 1
+		pRes.GameResult = models.Loss
+	}
+
+	// every member plays Elo game against average rating of the other team
+	for _, p := range append(remaining, r.left...) {
+		pRes := results[p.GameSessionID]
+		t := pRes.Team - 1
+		k := float64(EloK)
+		if res.Reason == Disconnected && pRes.GameResult == models.Win {
+			k = EloDisconnectWinnerK
+		}
+		delta := 0.0
+		for o := range teams {
+			if o == t || members[o] == 0 {
+				continue
+			}
+			delta += eloDelta(k, p.Rating, ratingSum[o]/members[o], pRes.GameResult) / float64(TeamsCount-1)
+		}
+		pRes.RatingDelta = int(math.Round(delta))
+	}
+
+	return results
+}
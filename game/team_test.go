@@ -0,0 +1,71 @@
+package game
+
+import (
+	"testing"
+
+	"game/models"
+)
+
+func TestCountTeamResults(t *testing.T) {
+	rules := DefaultGameRules()
+	tests := []struct {
+		name   string
+		scores []int // teams are 1, 2, 1, 2
+		left   []int
+		reason int
+		want   []participant
+	}{
+		{
+			name:   "team with more points wins",
+			scores: []int{4, 1, 6, 1},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 5), 1},
+				{2, models.Loss, rules.LoserCoinsAmount, -1},
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 5), 1},
+				{2, models.Loss, rules.LoserCoinsAmount, -1},
+			},
+		},
+		{
+			name:   "draw",
+			scores: []int{2, 3, 2, 1},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 2), 0},
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 2), 0},
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 2), 0},
+				{1, models.Draw, coins(rules.DrawCoinsCoefficient, 2), 0},
+			},
+		},
+		{
+			name:   "team whose players all left loses",
+			scores: []int{1, 10, 1, 10},
+			left:   []int{2, 4},
+			reason: Disconnected,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 1), 1},
+				{TeamsCount + 1, models.Loss, 0, -1},
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 1), 1},
+				{TeamsCount + 1, models.Loss, 0, -1},
+			},
+		},
+		{
+			name:   "left player loses with winning team",
+			scores: []int{5, 1, 5, 1},
+			left:   []int{3},
+			reason: TimeOver,
+			want: []participant{
+				{1, models.Win, coins(rules.WinnerCoinsCoefficient, 5), 1},
+				{2, models.Loss, rules.LoserCoinsAmount, -1},
+				{TeamsCount + 1, models.Loss, 0, -1},
+				{2, models.Loss, rules.LoserCoinsAmount, -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resultsRoom(ModeTeam, tt.scores, tt.left...)
+			checkResults(t, r.countResults(&Ended{Reason: tt.reason}), tt.want)
+		})
+	}
+}
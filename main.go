@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +28,14 @@ func main() {
 	dbConnStr := flag.String("db_connstr", "postgres@localhost:5432", "postgresql connection string")
 	dbName := flag.String("db_name", "postgres", "database name")
 	authConnStr := flag.String("auth_connstr", "localhost:8081", "auth-service connection string")
+	rulesPath := flag.String("game_rules", "", "JSON file with game rules, default rules are used if empty")
+	shutdownTimeout := flag.Duration("shutdown_timeout", 0,
+		"time for running games to end on shutdown, after it they are finished forcibly (game time + 5s if 0)")
+	botWait := flag.Duration("bot_wait", game.DefaultBotWait, "wait in matchmaking queue before playing with bot, 0 disables bots")
+	matchTimeout := flag.Duration("match_timeout", game.DefaultMatchTimeout,
+		"wait in matchmaking queue before giving up, 0 to wait forever (bot joins earlier if bot_wait is less)")
+	adminToken := flag.String("admin_token", os.Getenv("ADMIN_TOKEN"), "token for admin API, admin API is disabled if empty")
+	outboxDir := flag.String("outbox_dir", "/var/lib/dmstudio/outbox", "directory for match results not written to database yet")
 	flag.Parse()
 
 	l := logger.InitLogger()
@@ -31,7 +46,12 @@ func main() {
 		}
 	}()
 
-	prometheus.MustRegister(metrics.TotalRooms)
+	prometheus.MustRegister(metrics.TotalRooms, metrics.TotalSpectators,
+		metrics.PendingResults, metrics.FailedResultWrites, metrics.QuarantinedResults,
+		metrics.InputViolations, metrics.KickedPlayers,
+		metrics.TickDuration, metrics.WriteLatency, metrics.FinishedMatches,
+		metrics.WaitingPlayers, metrics.ReceivedInputs, metrics.UnknownActions,
+		metrics.FailedResultSaves, metrics.DroppedMessages, metrics.LaggingPlayers)
 
 	dm := database.InitDatabaseManager(*dbConnStr, *dbName)
 	defer dm.Close()
@@ -39,7 +59,24 @@ func main() {
 	sm := session.ConnectSessionManager(*authConnStr)
 	defer sm.Close()
 
-	g := game.InitGodGameObject(dm)
+	outbox, err := game.NewOutbox(*outboxDir)
+	if err != nil {
+		logger.Panic(err)
+	}
+
+	rules := game.DefaultGameRules()
+	if *rulesPath != "" {
+		rules, err = game.LoadGameRules(*rulesPath)
+		if err != nil {
+			logger.Panic(err)
+		}
+		logger.Infof("game rules are loaded from %v", *rulesPath)
+	}
+	if *shutdownTimeout == 0 {
+		*shutdownTimeout = rules.GameTime + 5*time.Second
+	}
+
+	g := game.InitGodGameObject(dm, outbox, rules, *botWait, *matchTimeout)
 	go g.Run()
 
 	http.Handle("/metrics", promhttp.Handler())
@@ -47,16 +84,68 @@ func main() {
 	http.HandleFunc("/game/ws", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
 		middleware.CORSMiddleware(middleware.SessionMiddleware(http.HandlerFunc(StartGame), sm)))))
 
-	logger.Info("starting server at: ", 8082)
-	logger.Panic(http.ListenAndServe(":8082", nil))
+	http.HandleFunc("/game/spectate", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+		middleware.CORSMiddleware(http.HandlerFunc(SpectateGame)))))
+
+	http.HandleFunc("/game/replay", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+		middleware.CORSMiddleware(http.HandlerFunc(WatchReplay)))))
+
+	http.HandleFunc("/game/history", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+		middleware.CORSMiddleware(middleware.SessionMiddleware(http.HandlerFunc(GetMatchHistory), sm)))))
+
+	if *adminToken != "" {
+		http.HandleFunc("/admin/rooms", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+			AdminMiddleware(http.HandlerFunc(ListRooms), *adminToken))))
+
+		http.HandleFunc("/admin/room", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+			AdminMiddleware(http.HandlerFunc(InspectRoom), *adminToken))))
+
+		http.HandleFunc("/admin/room/finish", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+			AdminMiddleware(http.HandlerFunc(TerminateRoom), *adminToken))))
+
+		http.HandleFunc("/admin/room/kick", middleware.RecoverMiddleware(middleware.AccessLogMiddleware(
+			AdminMiddleware(http.HandlerFunc(KickPlayer), *adminToken))))
+	}
+
+	srv := &http.Server{Addr: ":8082"}
+	go func() {
+		logger.Info("starting server at: ", 8082)
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Panic(err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	<-stop
+	logger.Info("shutting down, draining rooms...")
+
+	// server still accepts connections to let players reconnect to running games
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	game.Shutdown(ctx)
+
+	ctx, cancelSrv := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelSrv()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Errorf("error while shutting down server: %v", err)
+	}
+	logger.Info("server stopped")
 }
 
 // @Summary Начать игру по WebSocket
 // @Description Инициализирует соединение для пользователя
 // @ID get-game-ws
+// @Param mode query string false "Режим игры: solo (по умолчанию) или team (2 на 2)"
+// @Param private query bool false "Создать приватную комнату с кодом приглашения"
+// @Param code query string false "Код приглашения в приватную комнату"
+// @Param bot query string false "Сразу играть с ботом: easy, normal или hard"
+// @Param rate query int false "Сколько стейтов в секунду присылать, по умолчанию как в комнате"
 // @Success 101 "Switching Protocols"
-// @Failure 400 "Нет нужных заголовков"
+// @Failure 400 "Нет нужных заголовков, неизвестный режим, уровень бота или частота стейтов"
 // @Failure 401 "Не вошел"
+// @Failure 404 "Неверный или просроченный код приглашения"
+// @Failure 503 "Сервер выключается"
 // @Router /game/ws [GET]
 func StartGame(w http.ResponseWriter, r *http.Request) {
 	u := &game.User{}
@@ -68,10 +157,42 @@ func StartGame(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
+	mode, err := game.ParseMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	u.Mode = mode
+	if bot := r.URL.Query().Get("bot"); bot != "" {
+		u.Bot, err = game.ParseBotLevel(bot)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if rate := r.URL.Query().Get("rate"); rate != "" {
+		u.Rate, err = strconv.Atoi(rate)
+		if err != nil || u.Rate <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	u.Private = r.URL.Query().Get("private") == "true"
+	u.Code = strings.ToUpper(r.URL.Query().Get("code"))
+	if u.Code != "" && !game.InviteExists(u.Code) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	// only players returning to their games are accepted on shutdown
+	if game.Draining() && !game.IsPlaying(u.UID) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
 	upgrader := websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
 			return true
 		},
+		Subprotocols: game.Subprotocols,
 	}
 
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -83,3 +204,157 @@ func StartGame(w http.ResponseWriter, r *http.Request) {
 
 	game.AddPlayer(u)
 }
+
+// @Summary Смотреть игру по WebSocket
+// @Description Подключает зрителя к идущей игре в комнате
+// @ID get-game-spectate
+// @Param id query string true "ID комнаты"
+// @Success 101 "Switching Protocols"
+// @Failure 400 "Нет нужных заголовков"
+// @Failure 404 "Комната не найдена или игра в ней еще не началась"
+// @Router /game/spectate [GET]
+func SpectateGame(w http.ResponseWriter, r *http.Request) {
+	roomID := r.URL.Query().Get("id")
+	if roomID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !game.RoomStarted(roomID) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+		Subprotocols: game.Subprotocols,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("Cannot upgrade connection: ", err)
+		return
+	}
+
+	err = game.AddSpectator(conn, roomID)
+	if err != nil {
+		_ = conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, err.Error()))
+		conn.Close()
+	}
+}
+
+// @Summary Смотреть запись игры по WebSocket
+// @Description Проигрывает записанную игру в комнате в реальном или ускоренном времени
+// @ID get-game-replay
+// @Param id query string true "ID комнаты"
+// @Param speed query number false "Ускорение, от 1 до 10"
+// @Success 101 "Switching Protocols"
+// @Failure 400 "Нет нужных заголовков или неверное ускорение"
+// @Failure 404 "Запись не найдена"
+// @Failure 500 "Ошибка в бд"
+// @Router /game/replay [GET]
+func WatchReplay(w http.ResponseWriter, r *http.Request) {
+	roomID := r.URL.Query().Get("id")
+	if roomID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	speed := 1.0
+	if s := r.URL.Query().Get("speed"); s != "" {
+		var err error
+		speed, err = strconv.ParseFloat(s, 64)
+		if err != nil || speed < 1 || speed > game.MaxReplaySpeed {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	rp, err := game.LoadReplay(roomID)
+	if err != nil {
+		if err == game.ErrNoReplay {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+		Subprotocols: game.Subprotocols,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("Cannot upgrade connection: ", err)
+		return
+	}
+
+	game.PlayReplay(conn, rp, speed)
+}
+
+const (
+	DefaultHistoryLimit = 10
+	MaxHistoryLimit     = 50
+)
+
+// @Summary Получить историю игр пользователя
+// @Description Возвращает вошедшему пользователю страницу истории игр (своей или другого пользователя), последние игры первыми
+// @ID get-game-history
+// @Produce json
+// @Param id query int false "ID пользователя, по умолчанию текущий"
+// @Param page query int false "Номер страницы, с 1"
+// @Param limit query int false "Игр на странице, до 50"
+// @Success 200 {object} models.MatchHistory "Пользователь найден, успешно"
+// @Failure 400 "Неправильные параметры"
+// @Failure 401 "Не вошел"
+// @Failure 500 "Ошибка в бд"
+// @Router /game/history [GET]
+func GetMatchHistory(w http.ResponseWriter, r *http.Request) {
+	if !r.Context().Value(middleware.KeyIsAuthenticated).(bool) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	uID := r.Context().Value(middleware.KeyUserID).(uint)
+	if id := r.URL.Query().Get("id"); id != "" {
+		parsed, err := strconv.ParseUint(id, 10, 0)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		uID = uint(parsed)
+	}
+	page, limit := 1, DefaultHistoryLimit
+	var err error
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > MaxHistoryLimit {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	history, err := game.GetMatchHistory(uID, limit, (page-1)*limit)
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	j, err := history.MarshalJSON()
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(j)
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -14,6 +16,83 @@ var (
 		Name:      "total_rooms",
 		Help:      "Count of alive game rooms",
 	})
+	TotalSpectators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "total_spectators",
+		Help:      "Count of spectators watching games",
+	})
+	PendingResults = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "pending_results",
+		Help:      "Count of match results in outbox waiting to be written to database",
+	})
+	FailedResultWrites = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "failed_result_writes_total",
+		Help:      "Count of failed attempts to write match results to database",
+	})
+	InputViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "input_violations_total",
+		Help:      "Count of seconds with impossible input from players by type of violation (rate)",
+	}, []string{"type"})
+	KickedPlayers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "kicked_players_total",
+		Help:      "Count of players kicked from games by reason (cheating, admin)",
+	}, []string{"reason"})
+	QuarantinedResults = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "quarantined_results_total",
+		Help:      "Count of match results moved out of outbox after failing to be written to database",
+	})
+	TickDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "tick_duration_seconds",
+		Help:      "Time of processing the engine tick in room, including catching up frames",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .016, .025, .05},
+	})
+	WriteLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "ws_write_seconds",
+		Help:      "Time of writing a message to player's websocket connection",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .25, .5, 1},
+	})
+	FinishedMatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "finished_matches_total",
+		Help:      "Count of finished matches by end reason (time_over, disconnected)",
+	}, []string{"reason"})
+	WaitingPlayers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "waiting_players",
+		Help:      "Count of players waiting in matchmaking queue",
+	})
+	ReceivedInputs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "received_inputs_total",
+		Help:      "Count of inputs received from players, use rate() for inputs per second",
+	})
+	UnknownActions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "unknown_actions_total",
+		Help:      "Count of messages with unknown action masks",
+	})
+	FailedResultSaves = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "failed_result_saves_total",
+		Help:      "Count of match results neither written to database nor put to outbox",
+	})
+	DroppedMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "dropped_messages_total",
+		Help:      "Count of messages to players dropped because SendMessage channel is full by status",
+	}, []string{"status"})
+	LaggingPlayers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: PrometheusNamespace,
+		Name:      "lagging_players_total",
+		Help:      "Count of players disconnected for falling too far behind the game",
+	})
 )
 
 func AddRoomToCounter() {
@@ -23,3 +102,67 @@ func AddRoomToCounter() {
 func SubtractRoomFromCounter() {
 	TotalRooms.Dec()
 }
+
+func AddSpectatorToCounter() {
+	TotalSpectators.Inc()
+}
+
+func SubtractSpectatorFromCounter() {
+	TotalSpectators.Dec()
+}
+
+func AddPendingResult() {
+	PendingResults.Inc()
+}
+
+func SubtractPendingResult() {
+	PendingResults.Dec()
+}
+
+func AddInputViolation(violation string) {
+	InputViolations.WithLabelValues(violation).Inc()
+}
+
+func AddKickedPlayer(reason string) {
+	KickedPlayers.WithLabelValues(reason).Inc()
+}
+
+func ObserveTick(d time.Duration) {
+	TickDuration.Observe(d.Seconds())
+}
+
+func ObserveWrite(d time.Duration) {
+	WriteLatency.Observe(d.Seconds())
+}
+
+func AddFinishedMatch(reason string) {
+	FinishedMatches.WithLabelValues(reason).Inc()
+}
+
+func SetWaitingPlayers(n int) {
+	WaitingPlayers.Set(float64(n))
+}
+
+func AddReceivedInput() {
+	ReceivedInputs.Inc()
+}
+
+func AddUnknownActions() {
+	UnknownActions.Inc()
+}
+
+func AddFailedResultSave() {
+	FailedResultSaves.Inc()
+}
+
+func AddDroppedMessage(status string) {
+	DroppedMessages.WithLabelValues(status).Inc()
+}
+
+func AddLaggingPlayer() {
+	LaggingPlayers.Inc()
+}
+
+func AddQuarantinedResult() {
+	QuarantinedResults.Inc()
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	EndReasonTimeOver        = "time_over"
+	EndReasonDisconnected    = "disconnected"
+	EndReasonAdminTerminated = "admin_terminated"
+)
+
+//easyjson:json
+type Match struct {
+	RoomID       string              `json:"roomId" db:"room_id"`
+	Mode         string              `json:"mode" db:"mode"` // solo or team
+	Started      time.Time           `json:"started" db:"started"`
+	Ended        time.Time           `json:"ended" db:"ended"`
+	EndReason    string              `json:"endReason" db:"end_reason"`
+	Seed         int64               `json:"seed" db:"seed"`
+	Casual       bool                `json:"casual,omitempty" db:"casual"` // stats, coins and rating are not changed
+	Participants []*MatchParticipant `json:"participants"`
+}
+
+//easyjson:json
+type MatchParticipant struct {
+	UID         uint `json:"uid" db:"user_id"`
+	PlayerNum   int  `json:"playerNum" db:"player_num"`
+	Team        int  `json:"team,omitempty" db:"team"` // 1-2 in team mode
+	Place       int  `json:"place" db:"place"`         // players (teams) with the same score share the place
+	Score       int  `json:"score" db:"score"`
+	GameResult  int  `json:"gameResult" db:"game_result"` // Win, Loss or Draw (of the team in team mode)
+	Coins       int  `json:"coins" db:"coins"`
+	RatingDelta int  `json:"ratingDelta" db:"rating_delta"`
+	Flagged     bool `json:"flagged,omitempty" db:"flagged"` // suspected of cheating, got no rewards
+}
+
+//easyjson:json
+type MatchHistory []*Match